@@ -0,0 +1,185 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen - returned without calling the func when the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// CircuitState - state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed - calls are allowed, failures are counted.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen - calls fail fast with ErrCircuitOpen until the cool down has elapsed.
+	CircuitOpen
+	// CircuitHalfOpen - a single trial call is allowed, its result closes or re-opens the circuit.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker - stops calling a failing dependency until it has had time to recover. Safe for concurrent use.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// trial - true while the half open trial call is in flight.
+	trial bool
+
+	// threshold - consecutive failures before the circuit opens.
+	threshold int
+	// coolDown - time the circuit stays open before allowing a trial call.
+	coolDown time.Duration
+	now      func() time.Time
+}
+
+type CircuitBreakerOpts func(*CircuitBreaker)
+
+// NewCircuitBreaker - creates a new closed circuit breaker.
+// By default the circuit opens after 5 consecutive failures and cools down for 30 seconds.
+func NewCircuitBreaker(opts ...CircuitBreakerOpts) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		threshold: 5,
+		coolDown:  30 * time.Second,
+		now:       time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(cb)
+	}
+
+	return cb
+}
+
+// State - returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.coolDown {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// Do - calls fn if the circuit allows it, otherwise returns ErrCircuitOpen.
+// A panic within fn is recorded as a failure before it is re-raised.
+func (cb *CircuitBreaker) Do(fn func() error) error {
+	if !cb.allow() {
+		return ErrCircuitOpen
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			cb.record(fmt.Errorf("circuit breaker: panic: %v", r))
+			panic(r)
+		}
+	}()
+
+	err := fn()
+	cb.record(err)
+	return err
+}
+
+// allow - reports whether a call may proceed, moving an open circuit to half open once cooled down.
+func (cb *CircuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.coolDown {
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		cb.trial = true
+		return true
+	case CircuitHalfOpen:
+		if cb.trial {
+			return false
+		}
+		cb.trial = true
+		return true
+	}
+	return true
+}
+
+func (cb *CircuitBreaker) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.trial = false
+	if err == nil {
+		cb.state = CircuitClosed
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.threshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// CircuitBreakerFunc - wraps fn so it is only called when the circuit allows it.
+// When the circuit is open the wrapped func returns the nil value and ErrCircuitOpen.
+func CircuitBreakerFunc[T any, K any](cb *CircuitBreaker, fn func(T) (K, error)) func(T) (K, error) {
+	return func(input T) (K, error) {
+		var result K
+		err := cb.Do(func() error {
+			var err error
+			result, err = fn(input)
+			return err
+		})
+		return result, err
+	}
+}
+
+// TxnStepOptCircuitBreaker - guards the step's handler with the circuit breaker.
+// When the circuit is open the step fails fast with ErrCircuitOpen and the state unchanged, rolling back the transaction.
+func TxnStepOptCircuitBreaker[T any](cb *CircuitBreaker) TxnStepOpts[T] {
+	return func(s *TxnStep[T]) {
+		handler := s.handler
+		s.handler = func(state T) (T, error) {
+			result := state
+			err := cb.Do(func() error {
+				var err error
+				result, err = handler(state)
+				return err
+			})
+			return result, err
+		}
+	}
+}
+
+// CircuitBreakerOptThreshold - sets the consecutive failures before the circuit opens.
+func CircuitBreakerOptThreshold(threshold int) CircuitBreakerOpts {
+	return func(cb *CircuitBreaker) {
+		cb.threshold = threshold
+	}
+}
+
+// CircuitBreakerOptCoolDown - sets the time the circuit stays open before allowing a trial call.
+func CircuitBreakerOptCoolDown(coolDown time.Duration) CircuitBreakerOpts {
+	return func(cb *CircuitBreaker) {
+		cb.coolDown = coolDown
+	}
+}
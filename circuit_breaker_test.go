@@ -0,0 +1,159 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	expectedErr := errors.New("expected failure")
+
+	fail := func() error { return expectedErr }
+	succeed := func() error { return nil }
+
+	newBreaker := func() *CircuitBreaker {
+		cb := NewCircuitBreaker(CircuitBreakerOptThreshold(2), CircuitBreakerOptCoolDown(time.Minute))
+		cb.now = clock
+		return cb
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should open after consecutive failures reach threshold", func(t *testing.T) {
+			cb := newBreaker()
+
+			odize.AssertTrue(t, errors.Is(cb.Do(fail), expectedErr))
+			odize.AssertEqual(t, CircuitClosed, cb.State())
+			odize.AssertTrue(t, errors.Is(cb.Do(fail), expectedErr))
+			odize.AssertEqual(t, CircuitOpen, cb.State())
+
+			calls := 0
+			err := cb.Do(func() error {
+				calls++
+				return nil
+			})
+			odize.AssertTrue(t, errors.Is(err, ErrCircuitOpen))
+			odize.AssertEqual(t, 0, calls)
+		}).
+		Test("should reset failures on success", func(t *testing.T) {
+			cb := newBreaker()
+
+			_ = cb.Do(fail)
+			_ = cb.Do(succeed)
+			_ = cb.Do(fail)
+
+			odize.AssertEqual(t, CircuitClosed, cb.State())
+		}).
+		Test("should close after successful trial once cooled down", func(t *testing.T) {
+			cb := newBreaker()
+			_ = cb.Do(fail)
+			_ = cb.Do(fail)
+
+			now = now.Add(time.Minute)
+			odize.AssertEqual(t, CircuitHalfOpen, cb.State())
+
+			odize.AssertNoError(t, cb.Do(succeed))
+			odize.AssertEqual(t, CircuitClosed, cb.State())
+		}).
+		Test("should re-open after failed trial", func(t *testing.T) {
+			cb := newBreaker()
+			_ = cb.Do(fail)
+			_ = cb.Do(fail)
+
+			now = now.Add(time.Minute)
+			odize.AssertTrue(t, errors.Is(cb.Do(fail), expectedErr))
+
+			odize.AssertEqual(t, CircuitOpen, cb.State())
+			odize.AssertTrue(t, errors.Is(cb.Do(succeed), ErrCircuitOpen))
+		}).
+		Test("should record a panic during the trial as a failure", func(t *testing.T) {
+			cb := newBreaker()
+			_ = cb.Do(fail)
+			_ = cb.Do(fail)
+
+			now = now.Add(time.Minute)
+			func() {
+				defer func() {
+					odize.AssertEqual(t, "boom", recover())
+				}()
+				_ = cb.Do(func() error { panic("boom") })
+			}()
+			odize.AssertEqual(t, CircuitOpen, cb.State())
+
+			now = now.Add(time.Minute)
+			odize.AssertNoError(t, cb.Do(succeed))
+			odize.AssertEqual(t, CircuitClosed, cb.State())
+		}).
+		Test("should count a panic towards the threshold", func(t *testing.T) {
+			cb := newBreaker()
+			_ = cb.Do(fail)
+
+			func() {
+				defer func() { _ = recover() }()
+				_ = cb.Do(func() error { panic("boom") })
+			}()
+
+			odize.AssertEqual(t, CircuitOpen, cb.State())
+		}).
+		Test("should wrap funcs returning values", func(t *testing.T) {
+			cb := newBreaker()
+			double := CircuitBreakerFunc(cb, func(i int) (int, error) {
+				if i < 0 {
+					return 0, expectedErr
+				}
+				return i * 2, nil
+			})
+
+			result, err := double(2)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 4, result)
+
+			_, _ = double(-1)
+			_, _ = double(-1)
+			_, err = double(2)
+			odize.AssertTrue(t, errors.Is(err, ErrCircuitOpen))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestTxnStepOptCircuitBreaker(t *testing.T) {
+	type testState struct {
+		Name string
+	}
+
+	cb := NewCircuitBreaker(CircuitBreakerOptThreshold(1))
+	handlerCall := 0
+	rollbackCall := 0
+
+	newTxn := func() *Txn[testState] {
+		return NewTxn(testState{Name: "hello"}).Step(
+			func(ts testState) (testState, error) {
+				handlerCall++
+				return ts, fmt.Errorf("downstream unavailable")
+			},
+			func(ts testState) (testState, error) {
+				rollbackCall++
+				return ts, nil
+			},
+			TxnStepOptCircuitBreaker[testState](cb),
+		)
+	}
+
+	_, err := newTxn().Run()
+	odize.AssertFalse(t, errors.Is(err, ErrCircuitOpen))
+
+	result, err := newTxn().Run()
+	odize.AssertTrue(t, errors.Is(err, ErrCircuitOpen))
+	odize.AssertEqual(t, "hello", result.Name)
+	odize.AssertEqual(t, 1, handlerCall)
+	odize.AssertEqual(t, 2, rollbackCall)
+}
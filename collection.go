@@ -0,0 +1,116 @@
+package mewl
+
+import (
+	"iter"
+	"slices"
+)
+
+// Collection - chainable wrapper around a lazy sequence.
+// Chained operations run as a single pass when the collection is consumed, except Reverse and Sort which need the whole input.
+// Go methods cannot introduce type parameters or narrow T, so operations that change the element type or need comparable
+// elements are package functions, see CollectionMap, CollectionReduce, CollectionChunk and CollectionUnique.
+type Collection[T any] struct {
+	seq iter.Seq[T]
+}
+
+// NewCollection - creates a collection of the slice's elements.
+func NewCollection[T any](list []T) Collection[T] {
+	return Collection[T]{seq: SeqFromSlice(list)}
+}
+
+// CollectionFromSeq - creates a collection of the sequence's elements.
+func CollectionFromSeq[T any](seq iter.Seq[T]) Collection[T] {
+	return Collection[T]{seq: seq}
+}
+
+// Seq - returns the collection as a sequence.
+func (c Collection[T]) Seq() iter.Seq[T] {
+	return c.seq
+}
+
+// Slice - consumes the collection into a new slice.
+func (c Collection[T]) Slice() []T {
+	return SeqCollect(c.seq)
+}
+
+// Filter - keeps the elements that return true on the predicate func.
+func (c Collection[T]) Filter(fn PredicateFunc[T]) Collection[T] {
+	return Collection[T]{seq: SeqFilter(c.seq, fn)}
+}
+
+// Reverse - reverses the order of the elements. The whole input is read before the first element is yielded.
+func (c Collection[T]) Reverse() Collection[T] {
+	seq := c.seq
+	return Collection[T]{seq: func(yield func(T) bool) {
+		list := SeqCollect(seq)
+		for i := len(list) - 1; i >= 0; i-- {
+			if !yield(list[i]) {
+				return
+			}
+		}
+	}}
+}
+
+// Sort - stable sorts the elements using the compare func, which returns a negative number when a < b,
+// a positive number when a > b and zero when equal. The whole input is read before the first element is yielded.
+func (c Collection[T]) Sort(cmp func(a T, b T) int) Collection[T] {
+	seq := c.seq
+	return Collection[T]{seq: func(yield func(T) bool) {
+		list := SeqCollect(seq)
+		slices.SortStableFunc(list, cmp)
+		for _, item := range list {
+			if !yield(item) {
+				return
+			}
+		}
+	}}
+}
+
+// Take - keeps up to the first n elements.
+func (c Collection[T]) Take(n int) Collection[T] {
+	return Collection[T]{seq: SeqTake(c.seq, n)}
+}
+
+// Skip - drops the first n elements.
+func (c Collection[T]) Skip(n int) Collection[T] {
+	return Collection[T]{seq: SeqSkip(c.seq, n)}
+}
+
+// Find - returns the first element that satisfies the predicate func, stopping once found.
+func (c Collection[T]) Find(fn PredicateFunc[T]) (T, bool) {
+	return SeqFind(c.seq, fn)
+}
+
+// Some - checks if the predicate func returns truthy for any element, stopping at the first that does.
+func (c Collection[T]) Some(fn PredicateFunc[T]) bool {
+	return SeqSome(c.seq, fn)
+}
+
+// Every - tests whether all elements pass the predicate func, stopping at the first that does not.
+func (c Collection[T]) Every(fn PredicateFunc[T]) bool {
+	return SeqEvery(c.seq, fn)
+}
+
+// CollectionMap - maps every element of the collection to a new type.
+func CollectionMap[T any, K any](c Collection[T], fn MapperFunc[T, K]) Collection[K] {
+	return Collection[K]{seq: SeqMap(c.seq, fn)}
+}
+
+// CollectionUnique - keeps each element the first time it is seen.
+func CollectionUnique[T comparable](c Collection[T]) Collection[T] {
+	return Collection[T]{seq: SeqUnique(c.seq)}
+}
+
+// CollectionChunk - groups the elements of the collection into new slices of up to chunkSize elements.
+func CollectionChunk[T any](c Collection[T], chunkSize int) Collection[[]T] {
+	return Collection[[]T]{seq: SeqChunk(c.seq, chunkSize)}
+}
+
+// CollectionReduce - folds the collection into a single value of a new type, starting from initVal.
+func CollectionReduce[T any, K any](c Collection[T], initVal K, fn func(prev K, next T) K) K {
+	result := initVal
+	for item := range c.seq {
+		result = fn(result, item)
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package mewl
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestCollection(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should chain filters in a single pass", func(t *testing.T) {
+			visited := 0
+			list := []int{1, 2, 3, 4, 5, 6}
+
+			got := NewCollection(list).
+				Filter(func(item int) bool {
+					visited++
+					return item%2 == 0
+				}).
+				Take(2).
+				Slice()
+
+			odize.AssertEqual(t, []int{2, 4}, got)
+			odize.AssertEqual(t, 4, visited)
+		}).
+		Test("should keep unique elements in order", func(t *testing.T) {
+			got := CollectionUnique(NewCollection([]string{"a", "b", "a", "c"})).Slice()
+
+			odize.AssertEqual(t, []string{"a", "b", "c"}, got)
+		}).
+		Test("should reverse without mutating the input", func(t *testing.T) {
+			list := []int{1, 2, 3}
+
+			got := NewCollection(list).Reverse().Slice()
+
+			odize.AssertEqual(t, []int{3, 2, 1}, got)
+			odize.AssertEqual(t, []int{1, 2, 3}, list)
+		}).
+		Test("should stable sort", func(t *testing.T) {
+			list := []KeyVal{{Key: "b", Value: "1"}, {Key: "a", Value: "2"}, {Key: "b", Value: "3"}}
+
+			got := NewCollection(list).Sort(func(a, b KeyVal) int {
+				return cmp.Compare(a.Key, b.Key)
+			}).Slice()
+
+			odize.AssertEqual(t, []KeyVal{{Key: "a", Value: "2"}, {Key: "b", Value: "1"}, {Key: "b", Value: "3"}}, got)
+		}).
+		Test("should skip elements", func(t *testing.T) {
+			got := NewCollection([]int{1, 2, 3}).Skip(1).Slice()
+
+			odize.AssertEqual(t, []int{2, 3}, got)
+		}).
+		Test("should find, some and every", func(t *testing.T) {
+			c := NewCollection([]int{1, 2, 3})
+
+			got, ok := c.Find(func(item int) bool { return item > 1 })
+			odize.AssertTrue(t, ok)
+			odize.AssertEqual(t, 2, got)
+			odize.AssertTrue(t, c.Some(func(item int) bool { return item == 3 }))
+			odize.AssertFalse(t, c.Every(func(item int) bool { return item < 3 }))
+		}).
+		Test("should map, chunk and reduce to new types", func(t *testing.T) {
+			c := CollectionMap(NewCollection([]int{1, 2, 3}), func(item int) string {
+				return fmt.Sprint(item)
+			})
+
+			odize.AssertEqual(t, [][]string{{"1", "2"}, {"3"}}, CollectionChunk(c, 2).Slice())
+			odize.AssertEqual(t, "123", CollectionReduce(c, "", func(prev string, next string) string {
+				return prev + next
+			}))
+		}).
+		Test("should wrap sequences", func(t *testing.T) {
+			got := CollectionFromSeq(SeqFromSlice([]int{1, 2})).Slice()
+
+			odize.AssertEqual(t, []int{1, 2}, got)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExampleCollection() {
+	list := []int{5, 3, 8, 3, 1, 9}
+
+	got := CollectionUnique(NewCollection(list)).
+		Filter(func(item int) bool {
+			return item > 2
+		}).
+		Sort(cmp.Compare[int]).
+		Take(3).
+		Slice()
+
+	fmt.Println(got)
+	// Output: [3 5 8]
+}
@@ -0,0 +1,83 @@
+package mewl
+
+import "fmt"
+
+// IndexError - error returned by a callback for the element at Index.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %s", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// KeyError - error returned by a callback for the map entry at Key.
+type KeyError struct {
+	Key any
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("key %v: %s", e.Key, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// FailedIndices - returns the indices of every IndexError within the error, in the order they were joined.
+func FailedIndices(err error) []int {
+	if indexErr, ok := err.(*IndexError); ok {
+		return []int{indexErr.Index}
+	}
+
+	var result []int
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			result = append(result, FailedIndices(e)...)
+		}
+	}
+	return result
+}
+
+// FailedKeys - returns the keys of every KeyError within the error, in the order they were joined.
+func FailedKeys(err error) []any {
+	if keyErr, ok := err.(*KeyError); ok {
+		return []any{keyErr.Key}
+	}
+
+	var result []any
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			result = append(result, FailedKeys(e)...)
+		}
+	}
+	return result
+}
+
+type errConfig struct {
+	// collectAll - if set to true, every element is processed and all errors are returned.
+	collectAll bool
+}
+
+type ErrOpts func(*errConfig)
+
+func newErrConfig(opts []ErrOpts) errConfig {
+	config := errConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// ErrOptCollectAll - process every element and return all errors joined, rather than stopping at the first.
+func ErrOptCollectAll() ErrOpts {
+	return func(c *errConfig) {
+		c.collectAll = true
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/code-gorilla-au/mewl
 
-go 1.21.5
+go 1.23.0
 
 require (
 	github.com/code-gorilla-au/odize v1.0.1
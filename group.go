@@ -0,0 +1,62 @@
+package mewl
+
+// GroupBy - groups the elements of a list by the key returned from the mapper func.
+// Elements keep their input order within each group, duplicate elements are kept.
+// Each group is a new slice, the list is not modified.
+func GroupBy[T any, K comparable](list []T, fn MapperFunc[T, K]) map[K][]T {
+	result := make(map[K][]T)
+	for _, item := range list {
+		key := fn(item)
+		result[key] = append(result[key], item)
+	}
+	return result
+}
+
+// GroupByNested - groups the elements of a list by the outer key, then each group by the inner key.
+// Elements keep their input order within each group, duplicate elements are kept.
+func GroupByNested[T any, K comparable, V comparable](list []T, outer MapperFunc[T, K], inner MapperFunc[T, V]) map[K]map[V][]T {
+	result := make(map[K]map[V][]T)
+	for _, item := range list {
+		outerKey := outer(item)
+		if _, ok := result[outerKey]; !ok {
+			result[outerKey] = make(map[V][]T)
+		}
+
+		innerKey := inner(item)
+		result[outerKey][innerKey] = append(result[outerKey][innerKey], item)
+	}
+	return result
+}
+
+// KeyBy - indexes the elements of a list by the key returned from the mapper func.
+// If more than one element has the same key, the last element in the list wins.
+func KeyBy[T any, K comparable](list []T, fn MapperFunc[T, K]) map[K]T {
+	result := make(map[K]T, len(list))
+	for _, item := range list {
+		result[fn(item)] = item
+	}
+	return result
+}
+
+// CountBy - counts the elements of a list by the key returned from the mapper func.
+// Duplicate elements are counted every time they appear.
+func CountBy[T any, K comparable](list []T, fn MapperFunc[T, K]) map[K]int {
+	result := make(map[K]int)
+	for _, item := range list {
+		result[fn(item)]++
+	}
+	return result
+}
+
+// Partition - splits a list into the elements that return true on the predicate func and the rest.
+// Both lists keep the input order and are new slices, the list is not modified.
+func Partition[T any](list []T, fn PredicateFunc[T]) (matched []T, rest []T) {
+	for _, item := range list {
+		if fn(item) {
+			matched = append(matched, item)
+			continue
+		}
+		rest = append(rest, item)
+	}
+	return matched, rest
+}
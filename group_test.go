@@ -0,0 +1,134 @@
+package mewl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestGroupBy(t *testing.T) {
+	list := []KeyVal{
+		{Key: "a", Value: "1"},
+		{Key: "b", Value: "2"},
+		{Key: "a", Value: "3"},
+		{Key: "a", Value: "1"},
+	}
+
+	got := GroupBy(list, func(item KeyVal) string {
+		return item.Key
+	})
+
+	odize.AssertEqual(t, map[string][]KeyVal{
+		"a": {{Key: "a", Value: "1"}, {Key: "a", Value: "3"}, {Key: "a", Value: "1"}},
+		"b": {{Key: "b", Value: "2"}},
+	}, got)
+}
+
+func ExampleGroupBy() {
+	got := GroupBy([]int{1, 2, 3, 4, 5}, func(item int) bool {
+		return item%2 == 0
+	})
+
+	fmt.Println(got)
+	// Output: map[false:[1 3 5] true:[2 4]]
+}
+
+func TestGroupByNested(t *testing.T) {
+	list := []KeyVal{
+		{Key: "a", Value: "x"},
+		{Key: "b", Value: "x"},
+		{Key: "a", Value: "y"},
+		{Key: "a", Value: "x"},
+	}
+
+	got := GroupByNested(list,
+		func(item KeyVal) string { return item.Key },
+		func(item KeyVal) string { return item.Value },
+	)
+
+	odize.AssertEqual(t, map[string]map[string][]KeyVal{
+		"a": {
+			"x": {{Key: "a", Value: "x"}, {Key: "a", Value: "x"}},
+			"y": {{Key: "a", Value: "y"}},
+		},
+		"b": {
+			"x": {{Key: "b", Value: "x"}},
+		},
+	}, got)
+}
+
+func TestKeyBy(t *testing.T) {
+	list := []KeyVal{
+		{Key: "a", Value: "1"},
+		{Key: "b", Value: "2"},
+		{Key: "a", Value: "3"},
+	}
+
+	got := KeyBy(list, func(item KeyVal) string {
+		return item.Key
+	})
+
+	odize.AssertEqual(t, map[string]KeyVal{
+		"a": {Key: "a", Value: "3"},
+		"b": {Key: "b", Value: "2"},
+	}, got)
+}
+
+func ExampleKeyBy() {
+	list := []KeyVal{
+		{Key: "foo", Value: "bar"},
+		{Key: "bin", Value: "baz"},
+	}
+
+	got := KeyBy(list, func(item KeyVal) string {
+		return item.Key
+	})
+
+	fmt.Println(got)
+	// Output: map[bin:{bin baz} foo:{foo bar}]
+}
+
+func TestCountBy(t *testing.T) {
+	got := CountBy([]string{"one", "two", "three", "two"}, func(item string) int {
+		return len(item)
+	})
+
+	odize.AssertEqual(t, map[int]int{3: 3, 5: 1}, got)
+}
+
+func TestPartition(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should split list keeping order", func(t *testing.T) {
+			matched, rest := Partition([]int{1, 2, 3, 4, 5}, func(item int) bool {
+				return item%2 == 0
+			})
+
+			odize.AssertEqual(t, []int{2, 4}, matched)
+			odize.AssertEqual(t, []int{1, 3, 5}, rest)
+		}).
+		Test("should return nil lists for empty input", func(t *testing.T) {
+			var expected []int
+
+			matched, rest := Partition([]int{}, func(item int) bool {
+				return true
+			})
+
+			odize.AssertEqual(t, expected, matched)
+			odize.AssertEqual(t, expected, rest)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExamplePartition() {
+	matched, rest := Partition([]int{1, 2, 3, 4}, func(item int) bool {
+		return item > 2
+	})
+
+	fmt.Println(matched, rest)
+	// Output: [3 4] [1 2]
+}
@@ -81,3 +81,21 @@ func MapPickBy[T comparable, K any](obj map[T]K, fn PredicateFunc[K]) map[T]K {
 	}
 	return result
 }
+
+// MapEntries - returns the map's key value pairs. Like MapKeys, the order is not specified.
+func MapEntries[T comparable, K any](obj map[T]K) []Pair[T, K] {
+	result := make([]Pair[T, K], 0, len(obj))
+	for key, value := range obj {
+		result = append(result, NewPair(key, value))
+	}
+	return result
+}
+
+// MapFromEntries - creates a map from key value pairs. If a key appears more than once, the last pair wins.
+func MapFromEntries[T comparable, K any](entries []Pair[T, K]) map[T]K {
+	result := make(map[T]K, len(entries))
+	for _, entry := range entries {
+		result[entry.First] = entry.Second
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package mewl
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// MapOmitByErr - returns a partial copy of an object omitting values based on a fallible predicate func.
+// Keys are visited in sorted order, numbers and strings by value and other keys by their formatted value, so the errors returned are stable between calls.
+// By default it stops at the first error and returns a nil map.
+// With ErrOptCollectAll every value is tested, failed entries are left out of the result.
+// Errors wrap KeyError.
+func MapOmitByErr[T comparable, K any](obj map[T]K, fn func(item K) (bool, error), opts ...ErrOpts) (map[T]K, error) {
+	return mapFilterErr(obj, fn, false, opts)
+}
+
+// MapPickByErr - returns a partial copy of an object containing only the values specified by a fallible predicate func.
+// Keys are visited in sorted order, numbers and strings by value and other keys by their formatted value, so the errors returned are stable between calls.
+// By default it stops at the first error and returns a nil map.
+// With ErrOptCollectAll every value is tested, failed entries are left out of the result.
+// Errors wrap KeyError.
+func MapPickByErr[T comparable, K any](obj map[T]K, fn func(item K) (bool, error), opts ...ErrOpts) (map[T]K, error) {
+	return mapFilterErr(obj, fn, true, opts)
+}
+
+// MapValuesErr - returns a copy of an object with every value transformed by a fallible function.
+// Keys are visited in sorted order, numbers and strings by value and other keys by their formatted value, so the errors returned are stable between calls.
+// By default it stops at the first error and returns a nil map.
+// With ErrOptCollectAll every value is transformed, failed entries are left out of the result.
+// Errors wrap KeyError.
+func MapValuesErr[T comparable, K any, V any](obj map[T]K, fn func(item K) (V, error), opts ...ErrOpts) (map[T]V, error) {
+	config := newErrConfig(opts)
+
+	result := make(map[T]V, len(obj))
+	var errs []error
+	for _, key := range errKeyOrder(obj) {
+		value, err := fn(obj[key])
+		if err != nil {
+			errs = append(errs, &KeyError{Key: key, Err: err})
+			if !config.collectAll {
+				return nil, errors.Join(errs...)
+			}
+			continue
+		}
+		result[key] = value
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// mapFilterErr - copies the entries whose predicate result matches keep.
+func mapFilterErr[T comparable, K any](obj map[T]K, fn func(item K) (bool, error), keep bool, opts []ErrOpts) (map[T]K, error) {
+	config := newErrConfig(opts)
+
+	result := make(map[T]K, 0)
+	var errs []error
+	for _, key := range errKeyOrder(obj) {
+		ok, err := fn(obj[key])
+		if err != nil {
+			errs = append(errs, &KeyError{Key: key, Err: err})
+			if !config.collectAll {
+				return nil, errors.Join(errs...)
+			}
+			continue
+		}
+
+		if ok == keep {
+			result[key] = obj[key]
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// errKeyOrder - returns the map's keys in sorted order.
+// Keys of a number or string kind are compared by value, other keys are compared by their formatted value, formatted once per key.
+func errKeyOrder[T comparable, K any](obj map[T]K) []T {
+	type sortKey struct {
+		key   T
+		value reflect.Value
+	}
+
+	ordered := errKeyOrdered(reflect.TypeFor[T]().Kind())
+	sortKeys := make([]sortKey, 0, len(obj))
+	for key := range obj {
+		value := reflect.ValueOf(key)
+		if !ordered {
+			value = reflect.ValueOf(fmt.Sprint(key))
+		}
+		sortKeys = append(sortKeys, sortKey{key: key, value: value})
+	}
+
+	slices.SortFunc(sortKeys, func(a, b sortKey) int {
+		return errKeyCompare(a.value, b.value)
+	})
+
+	keys := make([]T, len(sortKeys))
+	for index, item := range sortKeys {
+		keys[index] = item.key
+	}
+	return keys
+}
+
+// errKeyOrdered - reports whether keys of the kind can be compared by value.
+func errKeyOrdered(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// errKeyCompare - compares two values of the same ordered kind.
+func errKeyCompare(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	default:
+		return cmp.Compare(a.String(), b.String())
+	}
+}
@@ -0,0 +1,83 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestMapPickByErr(t *testing.T) {
+	isEven := func(item string) (bool, error) {
+		i, err := strconv.Atoi(item)
+		return i%2 == 0, err
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should pick values", func(t *testing.T) {
+			got, err := MapPickByErr(map[string]string{"a": "1", "b": "2"}, isEven)
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, map[string]string{"b": "2"}, got)
+		}).
+		Test("should stop at first error in key order", func(t *testing.T) {
+			got, err := MapPickByErr(map[string]string{"a": "2", "b": "x", "c": "y"}, isEven)
+
+			odize.AssertEqual(t, []any{"b"}, FailedKeys(err))
+			odize.AssertTrue(t, errors.Is(err, strconv.ErrSyntax))
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Test("should collect all errors", func(t *testing.T) {
+			got, err := MapPickByErr(map[string]string{"a": "2", "b": "x", "c": "y"}, isEven, ErrOptCollectAll())
+
+			odize.AssertEqual(t, []any{"b", "c"}, FailedKeys(err))
+			odize.AssertEqual(t, map[string]string{"a": "2"}, got)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestMapOmitByErr(t *testing.T) {
+	isEven := func(item string) (bool, error) {
+		i, err := strconv.Atoi(item)
+		return i%2 == 0, err
+	}
+
+	got, err := MapOmitByErr(map[int]string{1: "1", 2: "2"}, isEven)
+	odize.AssertNoError(t, err)
+	odize.AssertEqual(t, map[int]string{1: "1"}, got)
+
+	_, err = MapOmitByErr(map[int]string{1: "x", 2: "2"}, isEven)
+	odize.AssertEqual(t, []any{1}, FailedKeys(err))
+
+	_, err = MapOmitByErr(map[int]string{10: "x", 9: "y", -1: "z"}, isEven, ErrOptCollectAll())
+	odize.AssertEqual(t, []any{-1, 9, 10}, FailedKeys(err))
+}
+
+func TestMapValuesErr(t *testing.T) {
+	got, err := MapValuesErr(map[string]string{"a": "1", "b": "2"}, strconv.Atoi)
+	odize.AssertNoError(t, err)
+	odize.AssertEqual(t, map[string]int{"a": 1, "b": 2}, got)
+
+	got, err = MapValuesErr(map[string]string{"a": "1", "b": "x"}, strconv.Atoi, ErrOptCollectAll())
+	odize.AssertEqual(t, []any{"b"}, FailedKeys(err))
+	odize.AssertEqual(t, map[string]int{"a": 1}, got)
+
+	type point struct{ X, Y int }
+	_, err = MapValuesErr(map[point]string{{X: 2}: "x", {X: 1, Y: 3}: "y"}, strconv.Atoi, ErrOptCollectAll())
+	odize.AssertEqual(t, []any{point{X: 1, Y: 3}, point{X: 2}}, FailedKeys(err))
+}
+
+func ExampleMapValuesErr() {
+	_, err := MapValuesErr(map[string]string{"a": "1", "b": "x", "c": "y"}, strconv.Atoi, ErrOptCollectAll())
+
+	fmt.Println(err)
+	// Output:
+	// key b: strconv.Atoi: parsing "x": invalid syntax
+	// key c: strconv.Atoi: parsing "y": invalid syntax
+}
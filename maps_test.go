@@ -198,3 +198,34 @@ func ExampleMapClone() {
 	fmt.Println(got)
 	// Output: map[hello:1 world:2]
 }
+
+func TestMapEntries(t *testing.T) {
+	got := MapEntries(map[string]int{"a": 1, "b": 2})
+	slices.SortFunc(got, CompareBy(func(p Pair[string, int]) string { return p.First }))
+
+	odize.AssertEqual(t, []Pair[string, int]{{First: "a", Second: 1}, {First: "b", Second: 2}}, got)
+	odize.AssertEqual(t, 0, len(MapEntries(map[string]int{})))
+}
+
+func TestMapFromEntries(t *testing.T) {
+	got := MapFromEntries([]Pair[string, int]{
+		NewPair("a", 1),
+		NewPair("b", 2),
+		NewPair("a", 3),
+	})
+
+	odize.AssertEqual(t, map[string]int{"a": 3, "b": 2}, got)
+}
+
+func TestMapEntries_round_trip(t *testing.T) {
+	obj := map[int]string{1: "one", 2: "two", 3: "three"}
+
+	odize.AssertEqual(t, obj, MapFromEntries(MapEntries(obj)))
+}
+
+func ExampleMapFromEntries() {
+	got := MapFromEntries(Zip([]string{"a", "b"}, []int{1, 2}))
+
+	fmt.Println(got)
+	// Output: map[a:1 b:2]
+}
@@ -0,0 +1,21 @@
+module github.com/code-gorilla-au/mewl/mewlotel
+
+go 1.23.0
+
+require (
+	github.com/code-gorilla-au/mewl v0.1.0
+	github.com/code-gorilla-au/odize v1.0.1
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/code-gorilla-au/env v0.0.0-20231101054621-7a54afca2a47 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.3.0 // indirect
+	github.com/joho/godotenv v1.5.1 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+)
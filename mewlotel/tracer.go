@@ -0,0 +1,87 @@
+// Package mewlotel provides an OpenTelemetry implementation of the mewl Tracer,
+// kept in its own module so mewl does not depend on OpenTelemetry.
+//
+// The module requires a published mewl version. To develop both modules against one checkout,
+// create an uncommitted workspace from the repository root:
+//
+//	go work init . ./mewlotel
+//	go work edit -replace github.com/code-gorilla-au/mewl@<required version>=./
+package mewlotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-gorilla-au/mewl"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer - adapts an OpenTelemetry tracer to mewl.Tracer.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer - creates a new tracer, use with mewl.TxnOptTracer.
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Start - starts a span as a child of any span within the context.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, mewl.Span) {
+	ctx, span := t.tracer.Start(ctx, name)
+	return ctx, &Span{span: span}
+}
+
+// Span - adapts an OpenTelemetry span to mewl.Span.
+type Span struct {
+	span trace.Span
+}
+
+// SetAttribute - sets an attribute on the span.
+func (s *Span) SetAttribute(key string, value any) {
+	s.span.SetAttributes(toAttribute(key, value))
+}
+
+// AddEvent - records a point in time event on the span.
+func (s *Span) AddEvent(name string, attributes map[string]any) {
+	var attrs []attribute.KeyValue
+	for key, value := range attributes {
+		attrs = append(attrs, toAttribute(key, value))
+	}
+
+	s.span.AddEvent(name, trace.WithAttributes(attrs...))
+}
+
+// RecordError - records an error on the span and marks the span as errored, nil errors are ignored.
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End - completes the span.
+func (s *Span) End() {
+	s.span.End()
+}
+
+func toAttribute(key string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,50 @@
+package mewlotel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/code-gorilla-au/mewl"
+	"github.com/code-gorilla-au/odize"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := NewTracer(provider.Tracer("mewl"))
+
+	txn := mewl.NewTxn(1, mewl.TxnOptTracer[int](tracer)).Step(
+		func(i int) (int, error) { return i, errors.New("expected failure") },
+		func(i int) (int, error) { return i, nil },
+		mewl.TxnStepOptName[int]("reserve"),
+	)
+
+	_, err := txn.Run()
+	odize.AssertError(t, err)
+
+	spans := recorder.Ended()
+	odize.AssertEqual(t, 3, len(spans))
+
+	step := spans[0]
+	odize.AssertEqual(t, "step reserve", step.Name())
+	odize.AssertEqual(t, codes.Error, step.Status().Code)
+	odize.AssertTrue(t, hasAttribute(step.Attributes(), attribute.String("txn.step.name", "reserve")))
+
+	root := spans[2]
+	odize.AssertEqual(t, "txn", root.Name())
+	odize.AssertEqual(t, root.SpanContext().SpanID(), step.Parent().SpanID())
+	odize.AssertTrue(t, hasAttribute(root.Attributes(), attribute.String("txn.status", "rolled_back")))
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,434 @@
+package mewl
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// ErrOrderedMapKeyType - the key type cannot be encoded as a JSON object key.
+var ErrOrderedMapKeyType = errors.New("unsupported ordered map key type")
+
+// OrderedMap - map that iterates in insertion order.
+// The zero value is an empty map ready to use. An OrderedMap is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*orderedMapEntry[K, V]
+	front   *orderedMapEntry[K, V]
+	back    *orderedMapEntry[K, V]
+}
+
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *orderedMapEntry[K, V]
+	next  *orderedMapEntry[K, V]
+}
+
+// NewOrderedMap - creates a new empty ordered map.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// Get - returns the value for the key and whether the key exists.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	entry, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Has - reports whether the key exists.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Set - sets the value for the key. New keys are added to the back, existing keys keep their position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if entry, ok := m.entries[key]; ok {
+		entry.value = value
+		return
+	}
+
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedMapEntry[K, V])
+	}
+
+	entry := &orderedMapEntry[K, V]{key: key, value: value}
+	m.entries[key] = entry
+	m.pushBack(entry)
+}
+
+// Delete - removes the key, returns false if the key does not exist.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	delete(m.entries, key)
+	m.unlink(entry)
+	return true
+}
+
+// MoveToFront - moves the key to the front of the map, returns false if the key does not exist.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	m.unlink(entry)
+	m.pushFront(entry)
+	return true
+}
+
+// MoveToBack - moves the key to the back of the map, returns false if the key does not exist.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	m.unlink(entry)
+	m.pushBack(entry)
+	return true
+}
+
+// Len - returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// All - returns a sequence of the key value pairs in insertion order.
+// The current key may be deleted while iterating.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for entry := m.front; entry != nil; {
+			next := entry.next
+			if !yield(entry.key, entry.value) {
+				return
+			}
+			entry = next
+		}
+	}
+}
+
+// Keys - returns the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	return SeqCollect(SeqKeys(m.All()))
+}
+
+// Values - returns the values in insertion order.
+func (m *OrderedMap[K, V]) Values() []V {
+	return SeqCollect(SeqValues(m.All()))
+}
+
+// Map - returns the entries as a built in map.
+func (m *OrderedMap[K, V]) Map() map[K]V {
+	result := make(map[K]V, m.Len())
+	for key, value := range m.All() {
+		result[key] = value
+	}
+	return result
+}
+
+// String - formats the entries in insertion order.
+func (m OrderedMap[K, V]) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("map[")
+	for entry := m.front; entry != nil; entry = entry.next {
+		if entry != m.front {
+			buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&buf, "%v:%v", entry.key, entry.value)
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+// MarshalJSON - encodes the map as an object with keys in insertion order.
+// Keys must be strings, integers or implement encoding.TextMarshaler.
+// Uses a value receiver so maps held by value in structs are encoded as objects too.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for entry := m.front; entry != nil; entry = entry.next {
+		if entry != m.front {
+			buf.WriteByte(',')
+		}
+
+		key, err := orderedMapEncodeKey(entry.key)
+		if err != nil {
+			return nil, err
+		}
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueData, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyData)
+		buf.WriteByte(':')
+		buf.Write(valueData)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON - decodes an object into the map, keeping the order the keys appear in.
+// Any existing entries are replaced, null leaves the map unchanged.
+// Keys must be strings, integers or implement encoding.TextUnmarshaler.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("ordered map: expected object, got %v", token)
+	}
+
+	result := OrderedMap[K, V]{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		key, err := orderedMapDecodeKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		result.Set(key, value)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	*m = result
+	return nil
+}
+
+func (m *OrderedMap[K, V]) pushFront(entry *orderedMapEntry[K, V]) {
+	entry.next = m.front
+	if m.front != nil {
+		m.front.prev = entry
+	}
+	m.front = entry
+	if m.back == nil {
+		m.back = entry
+	}
+}
+
+func (m *OrderedMap[K, V]) pushBack(entry *orderedMapEntry[K, V]) {
+	entry.prev = m.back
+	if m.back != nil {
+		m.back.next = entry
+	}
+	m.back = entry
+	if m.front == nil {
+		m.front = entry
+	}
+}
+
+func (m *OrderedMap[K, V]) unlink(entry *orderedMapEntry[K, V]) {
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		m.front = entry.next
+	}
+
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		m.back = entry.prev
+	}
+
+	entry.prev = nil
+	entry.next = nil
+}
+
+// orderedMapEncodeKey - formats a key following the encoding/json v1 rules for map keys,
+// string kinds are used as is before TextMarshaler is considered.
+func orderedMapEncodeKey[K comparable](key K) (string, error) {
+	value := reflect.ValueOf(key)
+	if value.Kind() == reflect.String {
+		return value.String(), nil
+	}
+
+	if marshaler, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("%w: %T", ErrOrderedMapKeyType, key)
+}
+
+// orderedMapDecodeKey - parses a key following the encoding/json v1 rules for map keys,
+// TextUnmarshaler is used before string kinds are considered.
+func orderedMapDecodeKey[K comparable](raw string) (K, error) {
+	var key K
+
+	if unmarshaler, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(raw))
+		return key, err
+	}
+
+	value := reflect.ValueOf(&key).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+		return key, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("ordered map: invalid key %q: %w", raw, err)
+		}
+		value.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("ordered map: invalid key %q: %w", raw, err)
+		}
+		value.SetUint(n)
+		return key, nil
+	}
+
+	return key, fmt.Errorf("%w: %T", ErrOrderedMapKeyType, key)
+}
+
+// OrderedMapClone - clones the provided ordered map.
+func OrderedMapClone[K comparable, V any](obj *OrderedMap[K, V]) *OrderedMap[K, V] {
+	return orderedMapFilter(obj, func(K, V) bool { return true })
+}
+
+// OrderedMapOmitKeys - returns a partial copy of an ordered map omitting the keys specified.
+// If the key does not exist, the property is ignored.
+func OrderedMapOmitKeys[K comparable, V any](obj *OrderedMap[K, V], omits ...K) *OrderedMap[K, V] {
+	omit := NewSet(omits...)
+	return orderedMapFilter(obj, func(key K, _ V) bool {
+		return !omit.Has(key)
+	})
+}
+
+// OrderedMapOmitBy - returns a partial copy of an ordered map omitting values based on a predicate func.
+func OrderedMapOmitBy[K comparable, V any](obj *OrderedMap[K, V], fn PredicateFunc[V]) *OrderedMap[K, V] {
+	return orderedMapFilter(obj, func(_ K, value V) bool {
+		return !fn(value)
+	})
+}
+
+// OrderedMapPickKeys - returns a partial copy of an ordered map containing only the keys specified.
+// The result keeps the order of obj, not the order of picks. If the key does not exist, the property is ignored.
+func OrderedMapPickKeys[K comparable, V any](obj *OrderedMap[K, V], picks ...K) *OrderedMap[K, V] {
+	pick := NewSet(picks...)
+	return orderedMapFilter(obj, func(key K, _ V) bool {
+		return pick.Has(key)
+	})
+}
+
+// OrderedMapPickBy - returns a partial copy of an ordered map containing only the values specified by a predicate func.
+func OrderedMapPickBy[K comparable, V any](obj *OrderedMap[K, V], fn PredicateFunc[V]) *OrderedMap[K, V] {
+	return orderedMapFilter(obj, func(_ K, value V) bool {
+		return fn(value)
+	})
+}
+
+// OrderedMapOmitByErr - returns a partial copy of an ordered map omitting values based on a fallible predicate func.
+// Keys are visited in order, see MapOmitByErr.
+func OrderedMapOmitByErr[K comparable, V any](obj *OrderedMap[K, V], fn func(item V) (bool, error), opts ...ErrOpts) (*OrderedMap[K, V], error) {
+	return orderedMapFilterErr(obj, fn, false, opts)
+}
+
+// OrderedMapPickByErr - returns a partial copy of an ordered map containing only the values specified by a fallible predicate func.
+// Keys are visited in order, see MapPickByErr.
+func OrderedMapPickByErr[K comparable, V any](obj *OrderedMap[K, V], fn func(item V) (bool, error), opts ...ErrOpts) (*OrderedMap[K, V], error) {
+	return orderedMapFilterErr(obj, fn, true, opts)
+}
+
+// OrderedMapValuesErr - returns a copy of an ordered map with every value transformed by a fallible function.
+// Keys are visited in order, see MapValuesErr.
+func OrderedMapValuesErr[K comparable, V any, R any](obj *OrderedMap[K, V], fn func(item V) (R, error), opts ...ErrOpts) (*OrderedMap[K, R], error) {
+	config := newErrConfig(opts)
+
+	result := NewOrderedMap[K, R]()
+	var errs []error
+	for key, value := range obj.All() {
+		mapped, err := fn(value)
+		if err != nil {
+			errs = append(errs, &KeyError{Key: key, Err: err})
+			if !config.collectAll {
+				return nil, errors.Join(errs...)
+			}
+			continue
+		}
+		result.Set(key, mapped)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// orderedMapFilter - copies the entries matching the predicate, keeping their order.
+func orderedMapFilter[K comparable, V any](obj *OrderedMap[K, V], fn func(key K, value V) bool) *OrderedMap[K, V] {
+	result := NewOrderedMap[K, V]()
+	for key, value := range obj.All() {
+		if fn(key, value) {
+			result.Set(key, value)
+		}
+	}
+	return result
+}
+
+// orderedMapFilterErr - copies the entries whose predicate result matches keep, keeping their order.
+func orderedMapFilterErr[K comparable, V any](obj *OrderedMap[K, V], fn func(item V) (bool, error), keep bool, opts []ErrOpts) (*OrderedMap[K, V], error) {
+	config := newErrConfig(opts)
+
+	result := NewOrderedMap[K, V]()
+	var errs []error
+	for key, value := range obj.All() {
+		ok, err := fn(value)
+		if err != nil {
+			errs = append(errs, &KeyError{Key: key, Err: err})
+			if !config.collectAll {
+				return nil, errors.Join(errs...)
+			}
+			continue
+		}
+
+		if ok == keep {
+			result.Set(key, value)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,357 @@
+package mewl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func newTestOrderedMap() *OrderedMap[string, int] {
+	m := NewOrderedMap[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("d", 4)
+	m.Set("b", 2)
+	return m
+}
+
+func TestOrderedMap(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("Set should keep insertion order", func(t *testing.T) {
+			m := newTestOrderedMap()
+
+			odize.AssertEqual(t, []string{"c", "a", "d", "b"}, m.Keys())
+			odize.AssertEqual(t, []int{3, 1, 4, 2}, m.Values())
+			odize.AssertEqual(t, 4, m.Len())
+		}).
+		Test("Set should update an existing key in place", func(t *testing.T) {
+			m := newTestOrderedMap()
+			m.Set("a", 10)
+
+			odize.AssertEqual(t, []string{"c", "a", "d", "b"}, m.Keys())
+			got, ok := m.Get("a")
+			odize.AssertTrue(t, ok)
+			odize.AssertEqual(t, 10, got)
+		}).
+		Test("Get should return false for a missing key", func(t *testing.T) {
+			got, ok := newTestOrderedMap().Get("z")
+
+			odize.AssertFalse(t, ok)
+			odize.AssertEqual(t, 0, got)
+		}).
+		Test("zero value should be usable", func(t *testing.T) {
+			var m OrderedMap[string, int]
+			odize.AssertFalse(t, m.Has("a"))
+
+			m.Set("a", 1)
+			odize.AssertTrue(t, m.Has("a"))
+		}).
+		Test("Delete should remove front, middle and back keys", func(t *testing.T) {
+			m := newTestOrderedMap()
+
+			odize.AssertTrue(t, m.Delete("a"))
+			odize.AssertTrue(t, m.Delete("c"))
+			odize.AssertTrue(t, m.Delete("b"))
+			odize.AssertFalse(t, m.Delete("b"))
+
+			odize.AssertEqual(t, []string{"d"}, m.Keys())
+
+			m.Set("e", 5)
+			odize.AssertEqual(t, []string{"d", "e"}, m.Keys())
+		}).
+		Test("MoveToFront and MoveToBack should reorder keys", func(t *testing.T) {
+			m := newTestOrderedMap()
+
+			odize.AssertTrue(t, m.MoveToFront("b"))
+			odize.AssertEqual(t, []string{"b", "c", "a", "d"}, m.Keys())
+
+			odize.AssertTrue(t, m.MoveToBack("c"))
+			odize.AssertEqual(t, []string{"b", "a", "d", "c"}, m.Keys())
+
+			odize.AssertTrue(t, m.MoveToFront("b"))
+			odize.AssertTrue(t, m.MoveToBack("c"))
+			odize.AssertEqual(t, []string{"b", "a", "d", "c"}, m.Keys())
+
+			odize.AssertFalse(t, m.MoveToFront("z"))
+			odize.AssertFalse(t, m.MoveToBack("z"))
+		}).
+		Test("All should allow deleting the current key", func(t *testing.T) {
+			m := newTestOrderedMap()
+			for key, value := range m.All() {
+				if value%2 == 0 {
+					m.Delete(key)
+				}
+			}
+
+			odize.AssertEqual(t, []string{"c", "a"}, m.Keys())
+		}).
+		Test("All should stop early", func(t *testing.T) {
+			var got []string
+			for key := range newTestOrderedMap().All() {
+				got = append(got, key)
+				if len(got) == 2 {
+					break
+				}
+			}
+
+			odize.AssertEqual(t, []string{"c", "a"}, got)
+		}).
+		Test("Map should return a built in map", func(t *testing.T) {
+			odize.AssertEqual(t, map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}, newTestOrderedMap().Map())
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+type upperKey string
+
+func (k upperKey) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(k))), nil
+}
+
+func (k *upperKey) UnmarshalText(text []byte) error {
+	*k = upperKey(strings.ToLower(string(text)))
+	return nil
+}
+
+type pointKey struct {
+	X int
+	Y int
+}
+
+func (k pointKey) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d", k.X, k.Y)), nil
+}
+
+func (k *pointKey) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d,%d", &k.X, &k.Y)
+	return err
+}
+
+func TestOrderedMap_json(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should marshal keys in insertion order", func(t *testing.T) {
+			data, err := json.Marshal(newTestOrderedMap())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, `{"c":3,"a":1,"d":4,"b":2}`, string(data))
+		}).
+		Test("should marshal an empty map as an empty object", func(t *testing.T) {
+			data, err := json.Marshal(NewOrderedMap[string, int]())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, `{}`, string(data))
+		}).
+		Test("should unmarshal keys in document order", func(t *testing.T) {
+			m := NewOrderedMap[string, []int]()
+			m.Set("old", nil)
+
+			err := json.Unmarshal([]byte(`{"z":[1],"a":[2,3],"m":[]}`), m)
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []string{"z", "a", "m"}, m.Keys())
+			got, _ := m.Get("a")
+			odize.AssertEqual(t, []int{2, 3}, got)
+		}).
+		Test("should round trip integer keys", func(t *testing.T) {
+			m := NewOrderedMap[int, string]()
+			m.Set(10, "ten")
+			m.Set(-2, "minus two")
+
+			data, err := json.Marshal(m)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"10":"ten","-2":"minus two"}`, string(data))
+
+			got := NewOrderedMap[int, string]()
+			odize.AssertNoError(t, json.Unmarshal(data, got))
+			odize.AssertEqual(t, []int{10, -2}, got.Keys())
+		}).
+		Test("should round trip text marshaler keys", func(t *testing.T) {
+			m := NewOrderedMap[pointKey, int]()
+			m.Set(pointKey{X: 2, Y: 3}, 1)
+			m.Set(pointKey{X: 1, Y: 0}, 2)
+
+			data, err := json.Marshal(m)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"2,3":1,"1,0":2}`, string(data))
+
+			got := NewOrderedMap[pointKey, int]()
+			odize.AssertNoError(t, json.Unmarshal(data, got))
+			odize.AssertEqual(t, []pointKey{{X: 2, Y: 3}, {X: 1, Y: 0}}, got.Keys())
+		}).
+		Test("should use string kinded keys as is when encoding", func(t *testing.T) {
+			// matches the encoding/json v1 rules, string kinds take precedence over TextMarshaler
+			m := NewOrderedMap[upperKey, int]()
+			m.Set("b", 1)
+
+			data, err := json.Marshal(m)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"b":1}`, string(data))
+		}).
+		Test("should prefer TextUnmarshaler when decoding", func(t *testing.T) {
+			got := NewOrderedMap[upperKey, int]()
+			odize.AssertNoError(t, json.Unmarshal([]byte(`{"B":1}`), got))
+
+			odize.AssertEqual(t, []upperKey{"b"}, got.Keys())
+		}).
+		Test("should treat null as a no-op", func(t *testing.T) {
+			type payload struct {
+				Items OrderedMap[string, int] `json:"items"`
+			}
+
+			var got payload
+			odize.AssertNoError(t, json.Unmarshal([]byte(`{"items":null}`), &got))
+			odize.AssertEqual(t, 0, got.Items.Len())
+
+			m := newTestOrderedMap()
+			odize.AssertNoError(t, json.Unmarshal([]byte(`null`), m))
+			odize.AssertEqual(t, 4, m.Len())
+		}).
+		Test("should round trip as a nested value", func(t *testing.T) {
+			type payload struct {
+				Items *OrderedMap[string, int] `json:"items"`
+			}
+
+			data, err := json.Marshal(payload{Items: newTestOrderedMap()})
+			odize.AssertNoError(t, err)
+
+			var got payload
+			odize.AssertNoError(t, json.Unmarshal(data, &got))
+			odize.AssertEqual(t, []string{"c", "a", "d", "b"}, got.Items.Keys())
+		}).
+		Test("should round trip as a struct field held by value", func(t *testing.T) {
+			type payload struct {
+				Items OrderedMap[string, int] `json:"items"`
+			}
+
+			data, err := json.Marshal(payload{Items: *newTestOrderedMap()})
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"items":{"c":3,"a":1,"d":4,"b":2}}`, string(data))
+
+			data, err = json.Marshal(payload{})
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"items":{}}`, string(data))
+
+			var got payload
+			odize.AssertNoError(t, json.Unmarshal([]byte(`{"items":{"z":1,"a":2}}`), &got))
+			odize.AssertEqual(t, []string{"z", "a"}, got.Items.Keys())
+		}).
+		Test("should return an error for an unsupported key type", func(t *testing.T) {
+			m := NewOrderedMap[float64, int]()
+			m.Set(1.5, 1)
+
+			_, err := json.Marshal(m)
+			odize.AssertTrue(t, errors.Is(err, ErrOrderedMapKeyType))
+
+			err = json.Unmarshal([]byte(`{"1.5":1}`), NewOrderedMap[float64, int]())
+			odize.AssertTrue(t, errors.Is(err, ErrOrderedMapKeyType))
+		}).
+		Test("should return an error for invalid json", func(t *testing.T) {
+			odize.AssertError(t, json.Unmarshal([]byte(`[1,2]`), NewOrderedMap[string, int]()))
+			odize.AssertError(t, json.Unmarshal([]byte(`{"a":"b"}`), NewOrderedMap[string, int]()))
+			odize.AssertError(t, json.Unmarshal([]byte(`{"x":1}`), NewOrderedMap[int, int]()))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestOrderedMap_helpers(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	isEven := func(item int) bool { return item%2 == 0 }
+	errOdd := errors.New("odd")
+	evenErr := func(item int) (bool, error) {
+		if item == 3 {
+			return false, errOdd
+		}
+		return isEven(item), nil
+	}
+
+	err := group.
+		Test("OrderedMapClone should copy entries in order", func(t *testing.T) {
+			m := newTestOrderedMap()
+			got := OrderedMapClone(m)
+			got.Set("e", 5)
+
+			odize.AssertEqual(t, []string{"c", "a", "d", "b"}, m.Keys())
+			odize.AssertEqual(t, []string{"c", "a", "d", "b", "e"}, got.Keys())
+		}).
+		Test("OrderedMapOmitKeys should omit keys", func(t *testing.T) {
+			got := OrderedMapOmitKeys(newTestOrderedMap(), "a", "z")
+
+			odize.AssertEqual(t, []string{"c", "d", "b"}, got.Keys())
+		}).
+		Test("OrderedMapOmitBy should omit matching values", func(t *testing.T) {
+			got := OrderedMapOmitBy(newTestOrderedMap(), isEven)
+
+			odize.AssertEqual(t, []string{"c", "a"}, got.Keys())
+		}).
+		Test("OrderedMapPickKeys should keep the source order", func(t *testing.T) {
+			got := OrderedMapPickKeys(newTestOrderedMap(), "b", "c", "z")
+
+			odize.AssertEqual(t, []string{"c", "b"}, got.Keys())
+		}).
+		Test("OrderedMapPickBy should pick matching values", func(t *testing.T) {
+			got := OrderedMapPickBy(newTestOrderedMap(), isEven)
+
+			odize.AssertEqual(t, []string{"d", "b"}, got.Keys())
+		}).
+		Test("OrderedMapPickByErr should stop at the first error", func(t *testing.T) {
+			got, err := OrderedMapPickByErr(newTestOrderedMap(), evenErr)
+
+			odize.AssertTrue(t, got == nil)
+			odize.AssertTrue(t, errors.Is(err, errOdd))
+			odize.AssertEqual(t, []any{"c"}, FailedKeys(err))
+		}).
+		Test("OrderedMapOmitByErr should collect all errors", func(t *testing.T) {
+			got, err := OrderedMapOmitByErr(newTestOrderedMap(), evenErr, ErrOptCollectAll())
+
+			odize.AssertEqual(t, []string{"a"}, got.Keys())
+			odize.AssertEqual(t, []any{"c"}, FailedKeys(err))
+		}).
+		Test("OrderedMapValuesErr should transform values in order", func(t *testing.T) {
+			got, err := OrderedMapValuesErr(newTestOrderedMap(), func(item int) (string, error) {
+				return fmt.Sprint(item * 10), nil
+			})
+
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, []string{"30", "10", "40", "20"}, got.Values())
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleOrderedMap() {
+	m := NewOrderedMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	m.Set("c", 3)
+	m.MoveToFront("c")
+	m.Delete("b")
+
+	data, _ := json.Marshal(m)
+	fmt.Println(m, string(data))
+	// Output: map[c:3 a:1] {"c":3,"a":1}
+}
+
+func ExampleOrderedMapPickBy() {
+	m := NewOrderedMap[string, int]()
+	m.Set("one", 1)
+	m.Set("two", 2)
+	m.Set("four", 4)
+
+	got := OrderedMapPickBy(m, func(item int) bool {
+		return item%2 == 0
+	})
+
+	fmt.Println(got.Keys())
+	// Output: [two four]
+}
@@ -0,0 +1,229 @@
+package mewl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox - messages enqueued by transaction steps, embed within the transaction state.
+// Messages are only saved to the outbox store once the transaction commits, see TxnOptOutbox.
+type Outbox[M any] struct {
+	pending []M
+}
+
+// Enqueue - adds messages to be saved when the transaction commits.
+func (o *Outbox[M]) Enqueue(msgs ...M) {
+	// copy on write, previous states returned by handlers must not share the backing array.
+	pending := make([]M, 0, len(o.pending)+len(msgs))
+	o.pending = append(append(pending, o.pending...), msgs...)
+}
+
+// Pending - returns messages that have been enqueued but not yet saved.
+func (o *Outbox[M]) Pending() []M {
+	result := make([]M, len(o.pending))
+	copy(result, o.pending)
+	return result
+}
+
+func (o *Outbox[M]) clear() {
+	o.pending = nil
+}
+
+// OutboxMessage - message saved within an outbox store.
+type OutboxMessage[M any] struct {
+	ID        string
+	Message   M
+	CreatedAt time.Time
+}
+
+// OutboxStore - local store of messages waiting to be published.
+type OutboxStore[M any] interface {
+	// Save - saves messages to be published, must be atomic.
+	Save(msgs []M) error
+	// Pending - returns up to limit unpublished messages, oldest first.
+	Pending(limit int) ([]OutboxMessage[M], error)
+	// MarkPublished - removes published messages from the store.
+	MarkPublished(ids ...string) error
+}
+
+// MemoryOutboxStore - in memory outbox store, safe for concurrent use.
+type MemoryOutboxStore[M any] struct {
+	mu       sync.Mutex
+	messages []OutboxMessage[M]
+}
+
+// NewMemoryOutboxStore - creates a new in memory outbox store.
+func NewMemoryOutboxStore[M any]() *MemoryOutboxStore[M] {
+	return &MemoryOutboxStore[M]{}
+}
+
+// Save - saves messages to be published.
+func (s *MemoryOutboxStore[M]) Save(msgs []M) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, msg := range msgs {
+		s.messages = append(s.messages, OutboxMessage[M]{
+			ID:        uuid.NewString(),
+			Message:   msg,
+			CreatedAt: now,
+		})
+	}
+	return nil
+}
+
+// Pending - returns up to limit unpublished messages, oldest first.
+func (s *MemoryOutboxStore[M]) Pending(limit int) ([]OutboxMessage[M], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if limit <= 0 || limit > len(s.messages) {
+		limit = len(s.messages)
+	}
+
+	result := make([]OutboxMessage[M], limit)
+	copy(result, s.messages[:limit])
+	return result, nil
+}
+
+// MarkPublished - removes published messages from the store.
+func (s *MemoryOutboxStore[M]) MarkPublished(ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = Filter(s.messages, func(item OutboxMessage[M]) bool {
+		for _, id := range ids {
+			if item.ID == id {
+				return false
+			}
+		}
+		return true
+	})
+	return nil
+}
+
+// OutboxPublisher - publishes a message, returning an error if it was not delivered.
+type OutboxPublisher[M any] func(ctx context.Context, msg OutboxMessage[M]) error
+
+// OutboxRelay - drains an outbox store to a publisher.
+// Messages are only removed from the store once published, delivery is at least once.
+type OutboxRelay[M any] struct {
+	store     OutboxStore[M]
+	publisher OutboxPublisher[M]
+
+	// batchSize - maximum number of messages read from the store per drain.
+	batchSize int
+	// interval - time between drains when running.
+	interval time.Duration
+}
+
+type OutboxRelayOpts[M any] func(*OutboxRelay[M])
+
+// NewOutboxRelay - creates a new relay, by default draining 100 messages every second.
+func NewOutboxRelay[M any](store OutboxStore[M], publisher OutboxPublisher[M], opts ...OutboxRelayOpts[M]) *OutboxRelay[M] {
+	r := &OutboxRelay[M]{
+		store:     store,
+		publisher: publisher,
+		batchSize: 100,
+		interval:  time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Drain - publishes a single batch of pending messages and returns the number published.
+// Draining stops at the first publish failure so messages are delivered in order, the failed message is retried on the next drain.
+func (r *OutboxRelay[M]) Drain(ctx context.Context) (int, error) {
+	msgs, err := r.store.Pending(r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("outbox drain failed: reading store: %w", err)
+	}
+
+	published := 0
+	for _, msg := range msgs {
+		if err := r.publisher(ctx, msg); err != nil {
+			return published, fmt.Errorf("outbox drain failed: message %s: %w", msg.ID, err)
+		}
+
+		if err := r.store.MarkPublished(msg.ID); err != nil {
+			return published, fmt.Errorf("outbox drain failed: marking message %s: %w", msg.ID, err)
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// Run - drains the outbox every interval until the context is done, returning the context's error.
+// Drain errors are retried on the next interval and do not stop the relay.
+func (r *OutboxRelay[M]) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// errors are retried on the next tick
+		_, _ = r.Drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// OutboxRelayOptBatchSize - sets the maximum number of messages read from the store per drain.
+func OutboxRelayOptBatchSize[M any](size int) OutboxRelayOpts[M] {
+	return func(r *OutboxRelay[M]) {
+		r.batchSize = size
+	}
+}
+
+// OutboxRelayOptInterval - sets the time between drains when running.
+func OutboxRelayOptInterval[M any](interval time.Duration) OutboxRelayOpts[M] {
+	return func(r *OutboxRelay[M]) {
+		r.interval = interval
+	}
+}
+
+// txnOutbox - saves or discards the outbox within a transaction's state.
+type txnOutbox[T any] struct {
+	save    func(state *T) error
+	discard func(state *T)
+}
+
+// TxnOptOutbox - saves messages enqueued in the state's outbox to the store when the transaction commits.
+// If the transaction rolls back the enqueued messages are discarded.
+// If saving fails the transaction is rolled back.
+// A transaction has a single outbox, a later TxnOptOutbox replaces an earlier one so a commit never saves to more than one store.
+func TxnOptOutbox[T any, M any](store OutboxStore[M], outbox func(state *T) *Outbox[M]) TxnOpts[T] {
+	return func(t *Txn[T]) {
+		t.outbox = &txnOutbox[T]{
+			save: func(state *T) error {
+				box := outbox(state)
+				if len(box.pending) == 0 {
+					return nil
+				}
+
+				if err := store.Save(box.pending); err != nil {
+					return fmt.Errorf("saving outbox: %w", err)
+				}
+
+				box.clear()
+				return nil
+			},
+			discard: func(state *T) {
+				outbox(state).clear()
+			},
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package mewl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+type orderState struct {
+	ID     string
+	Outbox Outbox[string]
+}
+
+func orderOutbox(state *orderState) *Outbox[string] {
+	return &state.Outbox
+}
+
+type failingOutboxStore struct {
+	*MemoryOutboxStore[string]
+}
+
+func (failingOutboxStore) Save(_ []string) error {
+	return fmt.Errorf("expected failure")
+}
+
+func TestTxnOptOutbox(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should save enqueued messages on commit", func(t *testing.T) {
+			store := NewMemoryOutboxStore[string]()
+
+			txn := NewTxn(orderState{ID: "1"}, TxnOptOutbox(store, orderOutbox))
+			result, err := txn.Step(
+				func(os orderState) (orderState, error) {
+					os.Outbox.Enqueue("OrderCreated")
+					return os, nil
+				},
+				func(os orderState) (orderState, error) {
+					return os, nil
+				},
+			).Run()
+			odize.AssertNoError(t, err)
+
+			pending, err := store.Pending(0)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 1, len(pending))
+			odize.AssertEqual(t, "OrderCreated", pending[0].Message)
+			odize.AssertEqual(t, 0, len(result.Outbox.Pending()))
+		}).
+		Test("should discard enqueued messages on rollback", func(t *testing.T) {
+			store := NewMemoryOutboxStore[string]()
+
+			txn := NewTxn(orderState{ID: "1"}, TxnOptOutbox(store, orderOutbox))
+			result, err := txn.
+				Step(
+					func(os orderState) (orderState, error) {
+						os.Outbox.Enqueue("OrderCreated")
+						return os, nil
+					},
+					func(os orderState) (orderState, error) {
+						return os, nil
+					},
+				).
+				Step(
+					func(os orderState) (orderState, error) {
+						return os, fmt.Errorf("expected failure")
+					},
+					func(os orderState) (orderState, error) {
+						return os, nil
+					},
+				).
+				Run()
+			odize.AssertError(t, err)
+
+			pending, err := store.Pending(0)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 0, len(pending))
+			odize.AssertEqual(t, 0, len(result.Outbox.Pending()))
+		}).
+		Test("should rollback when saving fails", func(t *testing.T) {
+			rollbackCall := 0
+
+			txn := NewTxn(orderState{ID: "1"}, TxnOptOutbox[orderState, string](failingOutboxStore{}, orderOutbox))
+			_, err := txn.Step(
+				func(os orderState) (orderState, error) {
+					os.Outbox.Enqueue("OrderCreated")
+					return os, nil
+				},
+				func(os orderState) (orderState, error) {
+					rollbackCall++
+					return os, nil
+				},
+			).Run()
+			odize.AssertEqual(t, "commit failed: saving outbox: expected failure", err.Error())
+
+			odize.AssertEqual(t, 1, rollbackCall)
+		}).
+		Test("should only save to the last outbox configured", func(t *testing.T) {
+			store := NewMemoryOutboxStore[string]()
+
+			txn := NewTxn(orderState{ID: "1"},
+				TxnOptOutbox(store, orderOutbox),
+				TxnOptOutbox[orderState, string](failingOutboxStore{}, orderOutbox),
+			)
+			_, err := txn.Step(
+				func(os orderState) (orderState, error) {
+					os.Outbox.Enqueue("OrderCreated")
+					return os, nil
+				},
+				nil,
+			).Run()
+			odize.AssertEqual(t, "commit failed: saving outbox: expected failure", err.Error())
+
+			pending, err := store.Pending(0)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 0, len(pending))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestOutboxRelay_Drain(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should publish and remove pending messages", func(t *testing.T) {
+			store := NewMemoryOutboxStore[string]()
+			odize.AssertNoError(t, store.Save([]string{"a", "b"}))
+
+			var published []string
+			relay := NewOutboxRelay(store, func(_ context.Context, msg OutboxMessage[string]) error {
+				published = append(published, msg.Message)
+				return nil
+			})
+
+			count, err := relay.Drain(context.Background())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, 2, count)
+			odize.AssertEqual(t, []string{"a", "b"}, published)
+
+			pending, err := store.Pending(0)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 0, len(pending))
+		}).
+		Test("should keep messages that fail to publish", func(t *testing.T) {
+			store := NewMemoryOutboxStore[string]()
+			odize.AssertNoError(t, store.Save([]string{"a", "b"}))
+
+			expectedErr := fmt.Errorf("expected failure")
+			relay := NewOutboxRelay(store, func(_ context.Context, msg OutboxMessage[string]) error {
+				if msg.Message == "b" {
+					return expectedErr
+				}
+				return nil
+			})
+
+			count, err := relay.Drain(context.Background())
+			odize.AssertTrue(t, errors.Is(err, expectedErr))
+			odize.AssertEqual(t, 1, count)
+
+			pending, err := store.Pending(0)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 1, len(pending))
+			odize.AssertEqual(t, "b", pending[0].Message)
+		}).
+		Test("should respect batch size", func(t *testing.T) {
+			store := NewMemoryOutboxStore[string]()
+			odize.AssertNoError(t, store.Save([]string{"a", "b", "c"}))
+
+			relay := NewOutboxRelay(store, func(_ context.Context, _ OutboxMessage[string]) error {
+				return nil
+			}, OutboxRelayOptBatchSize[string](2))
+
+			count, err := relay.Drain(context.Background())
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 2, count)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestOutboxRelay_Run(t *testing.T) {
+	store := NewMemoryOutboxStore[string]()
+	odize.AssertNoError(t, store.Save([]string{"a"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	relay := NewOutboxRelay(store, func(_ context.Context, _ OutboxMessage[string]) error {
+		cancel()
+		return nil
+	}, OutboxRelayOptInterval[string](time.Millisecond))
+
+	err := relay.Run(ctx)
+	odize.AssertTrue(t, errors.Is(err, context.Canceled))
+
+	pending, err := store.Pending(0)
+	odize.AssertNoError(t, err)
+	odize.AssertEqual(t, 0, len(pending))
+}
@@ -0,0 +1,188 @@
+package mewl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestParallelMap(t *testing.T) {
+	double := func(_ context.Context, item int) (int, error) {
+		return item * 2, nil
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should return results in input order", func(t *testing.T) {
+			list := make([]int, 1000)
+			for i := range list {
+				list[i] = i
+			}
+
+			got, err := ParallelMap(context.Background(), list, double, ParallelOptConcurrency(8))
+			odize.AssertNoError(t, err)
+
+			for i, item := range got {
+				odize.AssertEqual(t, i*2, item)
+			}
+		}).
+		Test("should not exceed concurrency", func(t *testing.T) {
+			var running atomic.Int32
+			var maxRunning atomic.Int32
+
+			_, err := ParallelMap(context.Background(), make([]int, 50), func(_ context.Context, item int) (int, error) {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for {
+					seen := maxRunning.Load()
+					if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				return item, nil
+			}, ParallelOptConcurrency(3), ParallelOptChunkSize(1))
+			odize.AssertNoError(t, err)
+
+			odize.AssertTrue(t, maxRunning.Load() <= 3)
+		}).
+		Test("should stop at first error", func(t *testing.T) {
+			expectedErr := errors.New("expected failure")
+			var calls atomic.Int32
+
+			got, err := ParallelMap(context.Background(), make([]int, 100), func(_ context.Context, item int) (int, error) {
+				calls.Add(1)
+				return 0, expectedErr
+			}, ParallelOptConcurrency(1), ParallelOptChunkSize(1))
+
+			odize.AssertTrue(t, errors.Is(err, expectedErr))
+			odize.AssertEqual(t, []int{0}, FailedIndices(err))
+			odize.AssertEqual(t, int32(1), calls.Load())
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Test("should report every failed index when collecting errors", func(t *testing.T) {
+			got, err := ParallelMap(context.Background(), []int{1, -2, 3, -4}, func(_ context.Context, item int) (int, error) {
+				if item < 0 {
+					return 0, fmt.Errorf("negative: %d", item)
+				}
+				return item, nil
+			}, ParallelOptCollectErrors(), ParallelOptConcurrency(2))
+
+			odize.AssertEqual(t, []int{1, 3}, FailedIndices(err))
+			odize.AssertEqual(t, []int{1, 0, 3, 0}, got)
+
+			var indexErr *IndexError
+			odize.AssertTrue(t, errors.As(err, &indexErr))
+		}).
+		Test("should stop when context is cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := ParallelMap(ctx, []int{1, 2, 3}, double)
+
+			odize.AssertTrue(t, errors.Is(err, context.Canceled))
+		}).
+		Test("should report cancellation when collecting errors", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			got, err := ParallelMap(ctx, make([]int, 8), func(_ context.Context, item int) (int, error) {
+				cancel()
+				return 0, errors.New("bad")
+			}, ParallelOptCollectErrors(), ParallelOptConcurrency(1), ParallelOptChunkSize(1))
+
+			odize.AssertTrue(t, errors.Is(err, context.Canceled))
+			odize.AssertEqual(t, []int{0}, FailedIndices(err))
+			odize.AssertEqual(t, 8, len(got))
+		}).
+		Test("should report cancellation when collecting errors without failures", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			got, err := ParallelMap(ctx, []int{1, 2, 3}, func(_ context.Context, item int) (int, error) {
+				cancel()
+				return item, nil
+			}, ParallelOptCollectErrors(), ParallelOptConcurrency(1), ParallelOptChunkSize(1))
+
+			odize.AssertTrue(t, errors.Is(err, context.Canceled))
+			odize.AssertEqual(t, []int{1, 0, 0}, got)
+		}).
+		Test("should handle empty input", func(t *testing.T) {
+			got, err := ParallelMap(context.Background(), []int{}, double)
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestParallelFilter(t *testing.T) {
+	got, err := ParallelFilter(context.Background(), []int{1, 2, 3, 4, 5, 6}, func(_ context.Context, item int) (bool, error) {
+		return item%2 == 0, nil
+	}, ParallelOptConcurrency(3))
+	odize.AssertNoError(t, err)
+
+	odize.AssertEqual(t, []int{2, 4, 6}, got)
+}
+
+func TestParallelForEach(t *testing.T) {
+	var total atomic.Int64
+
+	err := ParallelForEach(context.Background(), []int{1, 2, 3}, func(_ context.Context, item int) error {
+		total.Add(int64(item))
+		return nil
+	})
+	odize.AssertNoError(t, err)
+
+	odize.AssertEqual(t, int64(6), total.Load())
+}
+
+func TestDefaultParallelChunkSize(t *testing.T) {
+	odize.AssertEqual(t, 1, defaultParallelChunkSize(10, 4))
+	odize.AssertEqual(t, 31, defaultParallelChunkSize(1000, 4))
+	odize.AssertEqual(t, 256, defaultParallelChunkSize(1_000_000, 4))
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	list := make([]int, 100_000)
+	for i := range list {
+		list[i] = i
+	}
+
+	square := func(_ context.Context, item int) (int, error) {
+		return item * item, nil
+	}
+
+	for _, chunkSize := range []int{1, 16, 64, 256, 1024, 0} {
+		name := fmt.Sprintf("chunk_%d", chunkSize)
+		if chunkSize == 0 {
+			name = "chunk_default"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = ParallelMap(context.Background(), list, square, ParallelOptChunkSize(chunkSize))
+			}
+		})
+	}
+}
+
+func ExampleParallelMap() {
+	got, err := ParallelMap(context.Background(), []int{1, 2, 3}, func(_ context.Context, item int) (string, error) {
+		return fmt.Sprint(item * 2), nil
+	}, ParallelOptConcurrency(2))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(got)
+	// Output: [2 4 6]
+}
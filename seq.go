@@ -0,0 +1,425 @@
+package mewl
+
+import (
+	"iter"
+	"slices"
+)
+
+// SeqFromSlice - returns a sequence of the slice's elements.
+func SeqFromSlice[T any](list []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range list {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFromMap - returns a sequence of the map's key value pairs, in Go's map iteration order.
+func SeqFromMap[T comparable, K any](obj map[T]K) iter.Seq2[T, K] {
+	return func(yield func(T, K) bool) {
+		for key, value := range obj {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFromChan - returns a sequence of values received from the channel until it is closed.
+// If iteration stops early the remaining values are left in the channel.
+func SeqFromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range ch {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SeqKeys - returns a sequence of the keys of a key value sequence.
+func SeqKeys[T any, K any](seq iter.Seq2[T, K]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for key := range seq {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// SeqValues - returns a sequence of the values of a key value sequence.
+func SeqValues[T any, K any](seq iter.Seq2[T, K]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, value := range seq {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// SeqCollect - collects the sequence into a new slice. Returns nil for an empty sequence.
+func SeqCollect[T any](seq iter.Seq[T]) []T {
+	var result []T
+	for item := range seq {
+		result = append(result, item)
+	}
+	return result
+}
+
+// SeqFilter - lazily yields the elements that return true on the predicate func.
+func SeqFilter[T any](seq iter.Seq[T], fn PredicateFunc[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if fn(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SeqMap - lazily yields the result of calling the mapper func on every element.
+func SeqMap[T any, K any](seq iter.Seq[T], fn MapperFunc[T, K]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for item := range seq {
+			if !yield(fn(item)) {
+				return
+			}
+		}
+	}
+}
+
+// SeqForEach - iterates over the sequence and invokes the function on the element.
+func SeqForEach[T any](seq iter.Seq[T], fn func(item T)) {
+	for item := range seq {
+		fn(item)
+	}
+}
+
+// SeqUnique - lazily yields each element the first time it is seen.
+func SeqUnique[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return SeqUnion(seq)
+}
+
+// SeqUnion - lazily yields the elements of each sequence in order, skipping elements already yielded.
+func SeqUnion[T comparable](seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		cache := make(map[T]struct{}, 0)
+
+		for _, seq := range seqs {
+			for item := range seq {
+				if _, ok := cache[item]; ok {
+					continue
+				}
+
+				cache[item] = struct{}{}
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqWithout - lazily yields the elements not equal to any of the given values.
+func SeqWithout[T comparable](seq iter.Seq[T], omit ...T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if slices.Contains(omit, item) {
+				continue
+			}
+
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SeqChunk - lazily yields new slices of up to chunkSize elements. A chunkSize less than 1 is treated as 1.
+func SeqChunk[T any](seq iter.Seq[T], chunkSize int) iter.Seq[[]T] {
+	chunkSize = max(chunkSize, 1)
+
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, chunkSize)
+		for item := range seq {
+			chunk = append(chunk, item)
+			if len(chunk) < chunkSize {
+				continue
+			}
+
+			if !yield(chunk) {
+				return
+			}
+			chunk = make([]T, 0, chunkSize)
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// SeqTake - lazily yields up to the first n elements, stopping iteration once taken.
+func SeqTake[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		taken := 0
+		for item := range seq {
+			if !yield(item) {
+				return
+			}
+
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// SeqSkip - lazily yields the elements after the first n.
+func SeqSkip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		for item := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFlatten - lazily yields the elements of every slice in turn.
+func SeqFlatten[T any](seq iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for list := range seq {
+			for _, item := range list {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqFlatMap - lazily yields the elements of the sequence returned by the function for every element.
+func SeqFlatMap[T any, K any](seq iter.Seq[T], fn func(item T) iter.Seq[K]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for item := range seq {
+			for mapped := range fn(item) {
+				if !yield(mapped) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqFlattenDepth - lazily yields the elements, flattening nested []any up to depth levels.
+// A depth less than 0 flattens every level, other slice types are yielded as elements.
+func SeqFlattenDepth(seq iter.Seq[any], depth int) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for item := range seq {
+			if !flattenDepth(item, depth, yield) {
+				return
+			}
+		}
+	}
+}
+
+// flattenDepth - yields the item, or its elements if it is a []any and depth allows. Returns false once yield stops.
+func flattenDepth(item any, depth int, yield func(any) bool) bool {
+	nested, ok := item.([]any)
+	if !ok || depth == 0 {
+		return yield(item)
+	}
+
+	for _, child := range nested {
+		if !flattenDepth(child, depth-1, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// SeqFind - returns the first element that satisfies the predicate func, stopping iteration once found.
+// If item is not found return nil value.
+func SeqFind[T any](seq iter.Seq[T], fn PredicateFunc[T]) (T, bool) {
+	for item := range seq {
+		if fn(item) {
+			return item, true
+		}
+	}
+
+	var nilValue T
+	return nilValue, false
+}
+
+// SeqEvery - tests whether all elements pass the predicate func, stopping iteration at the first that does not.
+func SeqEvery[T any](seq iter.Seq[T], fn PredicateFunc[T]) bool {
+	for item := range seq {
+		if !fn(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SeqSome - checks if the predicate func returns truthy for any element, stopping iteration at the first that does.
+func SeqSome[T any](seq iter.Seq[T], fn PredicateFunc[T]) bool {
+	_, ok := SeqFind(seq, fn)
+	return ok
+}
+
+// SeqReduce - executes the reducer func on each element in order, passing in the return value from the preceding element.
+func SeqReduce[T any](seq iter.Seq[T], fn func(prev T, next T) T) ComposeFunc[T] {
+	return func(initVal T) T {
+		result := initVal
+		for item := range seq {
+			result = fn(result, item)
+		}
+		return result
+	}
+}
+
+// SeqWindow - lazily yields new slices of size consecutive elements, starting a window every step elements.
+// Windows overlap when step is less than size and only full windows are yielded. A size or step less than 1 is treated as 1.
+func SeqWindow[T any](seq iter.Seq[T], size int, step int) iter.Seq[[]T] {
+	size = max(size, 1)
+	step = max(step, 1)
+
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, size)
+		skip := 0
+		for item := range seq {
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			window = append(window, item)
+			if len(window) < size {
+				continue
+			}
+
+			if !yield(slices.Clone(window)) {
+				return
+			}
+
+			if step >= size {
+				window = window[:0]
+				skip = step - size
+				continue
+			}
+			window = append(window[:0], window[step:]...)
+		}
+	}
+}
+
+// SeqPairwise - lazily yields every element paired with the element after it.
+func SeqPairwise[T any](seq iter.Seq[T]) iter.Seq[Pair[T, T]] {
+	return func(yield func(Pair[T, T]) bool) {
+		var prev T
+		started := false
+		for item := range seq {
+			if started && !yield(NewPair(prev, item)) {
+				return
+			}
+
+			prev = item
+			started = true
+		}
+	}
+}
+
+// SeqScan - lazily yields the accumulator after calling the function on every element, starting from initVal.
+// initVal itself is not yielded.
+func SeqScan[T any, K any](seq iter.Seq[T], initVal K, fn func(acc K, item T) K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		acc := initVal
+		for item := range seq {
+			acc = fn(acc, item)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// SeqPrefixReduce - lazily yields the first element, then the result of reducing every element so far.
+func SeqPrefixReduce[T any](seq iter.Seq[T], fn func(prev T, next T) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var acc T
+		started := false
+		for item := range seq {
+			if started {
+				acc = fn(acc, item)
+			} else {
+				acc = item
+				started = true
+			}
+
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// SeqRunLengthEncode - lazily yields each run of equal consecutive elements as the element and the length of the run.
+// A run is yielded once the next different element is read, or the sequence ends.
+func SeqRunLengthEncode[T comparable](seq iter.Seq[T]) iter.Seq[Pair[T, int]] {
+	return func(yield func(Pair[T, int]) bool) {
+		run := Pair[T, int]{}
+		for item := range seq {
+			if run.Second > 0 && run.First == item {
+				run.Second++
+				continue
+			}
+
+			if run.Second > 0 && !yield(run) {
+				return
+			}
+			run = NewPair(item, 1)
+		}
+
+		if run.Second > 0 {
+			yield(run)
+		}
+	}
+}
+
+// SeqRunLengthDecode - lazily yields every element repeated by the length of its run. Runs with a length less than 1 are skipped.
+func SeqRunLengthDecode[T any](seq iter.Seq[Pair[T, int]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for run := range seq {
+			for i := 0; i < run.Second; i++ {
+				if !yield(run.First) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// seqIndexed - wraps a slice predicate func so it can be used on a sequence of the slice's elements.
+func seqIndexed[T any](list []T, fn PredicateSliceFunc[T]) PredicateFunc[T] {
+	index := -1
+	return func(item T) bool {
+		index++
+		return fn(item, index, list)
+	}
+}
@@ -0,0 +1,273 @@
+package mewl
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestSeqFromSlice(t *testing.T) {
+	got := SeqCollect(SeqFromSlice([]int{1, 2, 3}))
+
+	odize.AssertEqual(t, []int{1, 2, 3}, got)
+}
+
+func TestSeqFromMap(t *testing.T) {
+	keys := SeqCollect(SeqKeys(SeqFromMap(map[string]int{"a": 1, "b": 2})))
+	values := SeqCollect(SeqValues(SeqFromMap(map[string]int{"a": 1, "b": 2})))
+	slices.Sort(keys)
+	slices.Sort(values)
+
+	odize.AssertEqual(t, []string{"a", "b"}, keys)
+	odize.AssertEqual(t, []int{1, 2}, values)
+}
+
+func TestSeqFromChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	got := SeqCollect(SeqFromChan(ch))
+
+	odize.AssertEqual(t, []int{1, 2, 3}, got)
+}
+
+func TestSeqCollect_empty(t *testing.T) {
+	var expected []int
+
+	got := SeqCollect(SeqFilter(SeqFromSlice([]int{1}), func(item int) bool { return false }))
+
+	odize.AssertEqual(t, expected, got)
+}
+
+func TestSeqFilter(t *testing.T) {
+	got := SeqCollect(SeqFilter(SeqFromSlice([]int{1, 2, 3, 4}), func(item int) bool {
+		return item%2 == 0
+	}))
+
+	odize.AssertEqual(t, []int{2, 4}, got)
+}
+
+func TestSeqMap(t *testing.T) {
+	got := SeqCollect(SeqMap(SeqFromSlice([]int{1, 2}), func(item int) string {
+		return fmt.Sprint(item * 2)
+	}))
+
+	odize.AssertEqual(t, []string{"2", "4"}, got)
+}
+
+func TestSeqForEach(t *testing.T) {
+	total := 0
+
+	SeqForEach(SeqFromSlice([]int{1, 1, 2}), func(item int) {
+		total += item
+	})
+
+	odize.AssertEqual(t, 4, total)
+}
+
+func TestSeqUnique(t *testing.T) {
+	got := SeqCollect(SeqUnique(SeqFromSlice([]int{1, 2, 1, 3, 2})))
+
+	odize.AssertEqual(t, []int{1, 2, 3}, got)
+}
+
+func TestSeqUnion(t *testing.T) {
+	got := SeqCollect(SeqUnion(SeqFromSlice([]int{1, 2}), SeqFromSlice([]int{2, 3})))
+
+	odize.AssertEqual(t, []int{1, 2, 3}, got)
+}
+
+func TestSeqWithout(t *testing.T) {
+	got := SeqCollect(SeqWithout(SeqFromSlice([]int{1, 2, 3, 4}), 2, 4))
+
+	odize.AssertEqual(t, []int{1, 3}, got)
+}
+
+func TestSeqChunk(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should chunk with remainder", func(t *testing.T) {
+			got := SeqCollect(SeqChunk(SeqFromSlice([]int{1, 2, 3, 4, 5}), 2))
+
+			odize.AssertEqual(t, [][]int{{1, 2}, {3, 4}, {5}}, got)
+		}).
+		Test("should treat chunk size less than 1 as 1", func(t *testing.T) {
+			got := SeqCollect(SeqChunk(SeqFromSlice([]int{1, 2}), 0))
+
+			odize.AssertEqual(t, [][]int{{1}, {2}}, got)
+		}).
+		Test("should yield nothing for empty sequence", func(t *testing.T) {
+			got := SeqCollect(SeqChunk(SeqFromSlice([]int{}), 2))
+
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestSeqTake(t *testing.T) {
+	visited := 0
+	seq := SeqMap(SeqFromSlice([]int{1, 2, 3, 4}), func(item int) int {
+		visited++
+		return item
+	})
+
+	got := SeqCollect(SeqTake(seq, 2))
+
+	odize.AssertEqual(t, []int{1, 2}, got)
+	odize.AssertEqual(t, 2, visited)
+	odize.AssertEqual(t, 0, len(SeqCollect(SeqTake(seq, 0))))
+}
+
+func TestSeqSkip(t *testing.T) {
+	got := SeqCollect(SeqSkip(SeqFromSlice([]int{1, 2, 3, 4}), 3))
+
+	odize.AssertEqual(t, []int{4}, got)
+}
+
+func TestSeqFind(t *testing.T) {
+	visited := 0
+	seq := SeqMap(SeqFromSlice([]int{1, 2, 3, 4}), func(item int) int {
+		visited++
+		return item
+	})
+
+	got, ok := SeqFind(seq, func(item int) bool {
+		return item == 2
+	})
+
+	odize.AssertTrue(t, ok)
+	odize.AssertEqual(t, 2, got)
+	odize.AssertEqual(t, 2, visited)
+}
+
+func TestSeqEvery(t *testing.T) {
+	odize.AssertTrue(t, SeqEvery(SeqFromSlice([]int{2, 4}), func(item int) bool { return item%2 == 0 }))
+	odize.AssertFalse(t, SeqEvery(SeqFromSlice([]int{2, 3}), func(item int) bool { return item%2 == 0 }))
+}
+
+func TestSeqSome(t *testing.T) {
+	odize.AssertTrue(t, SeqSome(SeqFromSlice([]int{1, 2}), func(item int) bool { return item == 2 }))
+	odize.AssertFalse(t, SeqSome(SeqFromSlice([]int{1, 3}), func(item int) bool { return item == 2 }))
+}
+
+func TestSeqReduce(t *testing.T) {
+	add := SeqReduce(SeqFromSlice([]int{1, 2, 3}), func(prev, next int) int {
+		return prev + next
+	})
+
+	odize.AssertEqual(t, 7, add(1))
+}
+
+func ExampleSeqFilter() {
+	// chains stay lazy, only the elements needed to find the first match are mapped.
+	seq := SeqMap(
+		SeqFilter(SeqFromSlice([]int{1, 2, 3, 4, 5, 6}), func(item int) bool {
+			return item%2 == 0
+		}),
+		func(item int) int {
+			return item * 10
+		},
+	)
+
+	got, ok := SeqFind(seq, func(item int) bool {
+		return item > 20
+	})
+
+	fmt.Println(got, ok)
+	// Output: 40 true
+}
+
+func TestSeqFlatten(t *testing.T) {
+	got := SeqCollect(SeqTake(SeqFlatten(SeqChunk(SeqFromSlice([]int{1, 2, 3, 4, 5}), 2)), 3))
+
+	odize.AssertEqual(t, []int{1, 2, 3}, got)
+}
+
+func TestSeqFlatMap(t *testing.T) {
+	calls := 0
+	repeat := func(item int) iter.Seq[int] {
+		calls++
+		return func(yield func(int) bool) {
+			for i := 0; i < item; i++ {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+
+	got := SeqCollect(SeqTake(SeqFlatMap(SeqFromSlice([]int{1, 2, 3, 4}), repeat), 4))
+
+	odize.AssertEqual(t, []int{1, 2, 2, 3}, got)
+	odize.AssertEqual(t, 3, calls)
+}
+
+func TestSeqFlattenDepth(t *testing.T) {
+	list := []any{[]any{1, []any{2}}, []any{[]any{[]any{3}}}, 4}
+
+	got := SeqCollect(SeqTake(SeqFlattenDepth(SeqFromSlice(list), -1), 3))
+
+	odize.AssertEqual(t, []any{1, 2, 3}, got)
+}
+
+// seqNaturals - unbounded sequence of 1, 2, 3...
+func seqNaturals(yield func(int) bool) {
+	for i := 1; ; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+func TestSeqWindow_unbounded(t *testing.T) {
+	got := SeqCollect(SeqTake(SeqWindow(seqNaturals, 3, 2), 3))
+
+	odize.AssertEqual(t, [][]int{{1, 2, 3}, {3, 4, 5}, {5, 6, 7}}, got)
+}
+
+func TestSeqPairwise_unbounded(t *testing.T) {
+	got := SeqCollect(SeqTake(SeqPairwise(seqNaturals), 2))
+
+	odize.AssertEqual(t, []Pair[int, int]{NewPair(1, 2), NewPair(2, 3)}, got)
+}
+
+func TestSeqScan_unbounded(t *testing.T) {
+	got := SeqCollect(SeqTake(SeqScan(seqNaturals, "", func(acc string, item int) string {
+		return acc + fmt.Sprint(item)
+	}), 3))
+
+	odize.AssertEqual(t, []string{"1", "12", "123"}, got)
+}
+
+func TestSeqPrefixReduce_unbounded(t *testing.T) {
+	got := SeqCollect(SeqTake(SeqPrefixReduce(seqNaturals, func(prev int, next int) int {
+		return prev + next
+	}), 4))
+
+	odize.AssertEqual(t, []int{1, 3, 6, 10}, got)
+}
+
+func TestSeqRunLengthEncode_unbounded(t *testing.T) {
+	halves := SeqMap(seqNaturals, func(item int) int { return item / 2 })
+
+	got := SeqCollect(SeqTake(SeqRunLengthEncode(halves), 3))
+
+	odize.AssertEqual(t, []Pair[int, int]{NewPair(0, 1), NewPair(1, 2), NewPair(2, 2)}, got)
+}
+
+func TestSeqRunLengthDecode_stops_early(t *testing.T) {
+	runs := SeqFromSlice([]Pair[string, int]{NewPair("a", 1_000_000), NewPair("b", 1)})
+
+	got := SeqCollect(SeqTake(SeqRunLengthDecode(runs), 2))
+
+	odize.AssertEqual(t, []string{"a", "a"}, got)
+}
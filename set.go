@@ -0,0 +1,204 @@
+package mewl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// Set - unordered collection of unique items that iterates in insertion order.
+// The zero value is an empty set ready to use. A Set is not safe for concurrent use.
+type Set[T comparable] struct {
+	// items - items in insertion order, removed items leave a dead slot until the set is compacted.
+	items []T
+	// index - position of each item in items, a slot is alive only if its item maps back to it.
+	index map[T]int
+	// removed - number of dead slots in items.
+	removed int
+}
+
+// NewSet - creates a new set containing the items, duplicates are ignored.
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{}
+	s.Add(items...)
+	return s
+}
+
+// Add - adds items to the set, items already in the set keep their position.
+func (s *Set[T]) Add(items ...T) {
+	for _, item := range items {
+		if _, ok := s.index[item]; ok {
+			continue
+		}
+
+		if s.index == nil {
+			s.index = make(map[T]int, len(items))
+		}
+		s.index[item] = len(s.items)
+		s.items = append(s.items, item)
+	}
+}
+
+// Remove - removes items from the set, items not in the set are ignored.
+func (s *Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		position, ok := s.index[item]
+		if !ok {
+			continue
+		}
+
+		var zero T
+		delete(s.index, item)
+		s.items[position] = zero
+		s.removed++
+	}
+
+	if s.removed > len(s.items)/2 {
+		s.compact()
+	}
+}
+
+// Has - reports whether the item is in the set.
+func (s *Set[T]) Has(item T) bool {
+	_, ok := s.index[item]
+	return ok
+}
+
+// Len - returns the number of items in the set.
+func (s *Set[T]) Len() int {
+	return len(s.index)
+}
+
+// Slice - returns the items in insertion order as a new slice. Returns nil for an empty set.
+func (s *Set[T]) Slice() []T {
+	if s.Len() == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, s.Len())
+	for item := range s.All() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// All - returns a sequence of the items in insertion order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for position, item := range s.items {
+			if !s.alive(position, item) {
+				continue
+			}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Clone - returns a copy of the set.
+func (s *Set[T]) Clone() *Set[T] {
+	return NewSet(s.Slice()...)
+}
+
+// Union - returns a new set of the items in this set or any of the others.
+// Items are ordered by this set first, followed by each other set in turn.
+func (s *Set[T]) Union(others ...*Set[T]) *Set[T] {
+	result := s.Clone()
+	for _, other := range others {
+		result.Add(other.Slice()...)
+	}
+	return result
+}
+
+// Intersection - returns a new set of the items in both sets, in this set's order.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	return NewSet(Filter(s.Slice(), other.Has)...)
+}
+
+// Difference - returns a new set of the items in this set but not the other, in this set's order.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	return NewSet(Filter(s.Slice(), func(item T) bool {
+		return !other.Has(item)
+	})...)
+}
+
+// SymmetricDifference - returns a new set of the items in exactly one of the sets.
+// Items are ordered by this set first, followed by the other set.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	return s.Difference(other).Union(other.Difference(s))
+}
+
+// IsSubset - reports whether every item in this set is in the other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+
+	return SeqEvery(s.All(), other.Has)
+}
+
+// IsSuperset - reports whether every item in the other set is in this set.
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal - reports whether both sets contain the same items, regardless of order.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// String - formats the items in insertion order.
+func (s Set[T]) String() string {
+	return fmt.Sprint(s.Slice())
+}
+
+// MarshalJSON - encodes the set as an array in insertion order.
+// Uses a value receiver so sets held by value in structs are encoded as arrays too.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := s.Slice()
+	if items == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON - decodes an array into the set, duplicates are ignored. null leaves the set unchanged.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	*s = Set[T]{}
+	s.Add(items...)
+	return nil
+}
+
+// alive - reports whether the slot at position holds an item in the set.
+func (s *Set[T]) alive(position int, item T) bool {
+	current, ok := s.index[item]
+	return ok && current == position
+}
+
+// compact - drops the dead slots left by Remove, keeping the items in insertion order.
+func (s *Set[T]) compact() {
+	kept := 0
+	for position, item := range s.items {
+		if !s.alive(position, item) {
+			continue
+		}
+		s.items[kept] = item
+		s.index[item] = kept
+		kept++
+	}
+
+	clear(s.items[kept:])
+	s.items = s.items[:kept]
+	s.removed = 0
+}
@@ -0,0 +1,227 @@
+package mewl
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestSet(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("NewSet should ignore duplicates and keep insertion order", func(t *testing.T) {
+			s := NewSet(3, 1, 3, 2, 1)
+
+			odize.AssertEqual(t, []int{3, 1, 2}, s.Slice())
+			odize.AssertEqual(t, 3, s.Len())
+		}).
+		Test("zero value should be usable", func(t *testing.T) {
+			var s Set[string]
+			odize.AssertFalse(t, s.Has("a"))
+
+			s.Add("a")
+			odize.AssertTrue(t, s.Has("a"))
+		}).
+		Test("Add should keep the position of existing items", func(t *testing.T) {
+			s := NewSet(1, 2)
+			s.Add(3, 1)
+
+			odize.AssertEqual(t, []int{1, 2, 3}, s.Slice())
+		}).
+		Test("Remove should preserve the order of the remaining items", func(t *testing.T) {
+			s := NewSet(1, 2, 3, 4)
+			s.Remove(2, 5)
+
+			odize.AssertEqual(t, []int{1, 3, 4}, s.Slice())
+			odize.AssertFalse(t, s.Has(2))
+			odize.AssertTrue(t, s.Has(4))
+
+			s.Add(2)
+			odize.AssertEqual(t, []int{1, 3, 4, 2}, s.Slice())
+		}).
+		Test("Remove should handle many removals", func(t *testing.T) {
+			s := NewSet[int]()
+			for i := 0; i < 100_000; i++ {
+				s.Add(i)
+			}
+			for i := 0; i < 100_000; i += 2 {
+				s.Remove(i)
+			}
+
+			odize.AssertEqual(t, 50_000, s.Len())
+			odize.AssertEqual(t, []int{1, 3, 5}, SeqCollect(SeqTake(s.All(), 3)))
+		}).
+		Test("Remove should keep zero values after compacting", func(t *testing.T) {
+			s := NewSet(5, 0, 6, 7)
+			s.Remove(5)
+			odize.AssertEqual(t, []int{0, 6, 7}, s.Slice())
+
+			s.Remove(0, 6)
+			odize.AssertEqual(t, []int{7}, s.Slice())
+			odize.AssertFalse(t, s.Has(0))
+
+			s.Add(0)
+			odize.AssertEqual(t, []int{7, 0}, s.Slice())
+			odize.AssertEqual(t, 2, s.Len())
+		}).
+		Test("Slice should return nil for an empty set", func(t *testing.T) {
+			var expected []int
+			odize.AssertEqual(t, expected, NewSet[int]().Slice())
+		}).
+		Test("Slice should return a copy", func(t *testing.T) {
+			s := NewSet(1, 2)
+			got := s.Slice()
+			got[0] = 9
+
+			odize.AssertEqual(t, []int{1, 2}, s.Slice())
+		}).
+		Test("All should iterate in insertion order", func(t *testing.T) {
+			s := NewSet("c", "a", "b")
+
+			odize.AssertEqual(t, []string{"c", "a", "b"}, SeqCollect(s.All()))
+		}).
+		Test("Clone should not share state", func(t *testing.T) {
+			s := NewSet(1, 2)
+			clone := s.Clone()
+			clone.Add(3)
+
+			odize.AssertEqual(t, 2, s.Len())
+			odize.AssertEqual(t, 3, clone.Len())
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestSet_algebra(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(6, 4, 3, 5)
+
+	err := group.
+		Test("Union should contain items from all sets", func(t *testing.T) {
+			got := a.Union(b, NewSet(7, 1))
+
+			odize.AssertEqual(t, []int{1, 2, 3, 4, 6, 5, 7}, got.Slice())
+		}).
+		Test("Intersection should use the receiver's order", func(t *testing.T) {
+			odize.AssertEqual(t, []int{3, 4}, a.Intersection(b).Slice())
+			odize.AssertEqual(t, []int{4, 3}, b.Intersection(a).Slice())
+		}).
+		Test("Difference should contain items only in the receiver", func(t *testing.T) {
+			odize.AssertEqual(t, []int{1, 2}, a.Difference(b).Slice())
+		}).
+		Test("SymmetricDifference should contain items in exactly one set", func(t *testing.T) {
+			odize.AssertEqual(t, []int{1, 2, 6, 5}, a.SymmetricDifference(b).Slice())
+		}).
+		Test("operations should not modify the operands", func(t *testing.T) {
+			_ = a.Union(b)
+			_ = a.SymmetricDifference(b)
+
+			odize.AssertEqual(t, []int{1, 2, 3, 4}, a.Slice())
+			odize.AssertEqual(t, []int{6, 4, 3, 5}, b.Slice())
+		}).
+		Test("IsSubset and IsSuperset", func(t *testing.T) {
+			sub := NewSet(4, 2)
+
+			odize.AssertTrue(t, sub.IsSubset(a))
+			odize.AssertTrue(t, a.IsSuperset(sub))
+			odize.AssertFalse(t, a.IsSubset(sub))
+			odize.AssertFalse(t, sub.IsSubset(b))
+			odize.AssertTrue(t, NewSet[int]().IsSubset(a))
+		}).
+		Test("Equal should ignore order", func(t *testing.T) {
+			odize.AssertTrue(t, a.Equal(NewSet(4, 3, 2, 1)))
+			odize.AssertFalse(t, a.Equal(b))
+			odize.AssertFalse(t, a.Equal(NewSet(1, 2, 3)))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestSet_json(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should marshal as an array in insertion order", func(t *testing.T) {
+			data, err := json.Marshal(NewSet("b", "a", "c"))
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, `["b","a","c"]`, string(data))
+		}).
+		Test("should marshal an empty set as an empty array", func(t *testing.T) {
+			data, err := json.Marshal(&Set[int]{})
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, `[]`, string(data))
+		}).
+		Test("should unmarshal an array and drop duplicates", func(t *testing.T) {
+			var s Set[int]
+			err := json.Unmarshal([]byte(`[3,1,3,2]`), &s)
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []int{3, 1, 2}, s.Slice())
+		}).
+		Test("should round trip as a struct field", func(t *testing.T) {
+			type payload struct {
+				Tags *Set[string] `json:"tags"`
+			}
+
+			data, err := json.Marshal(payload{Tags: NewSet("x", "y")})
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"tags":["x","y"]}`, string(data))
+
+			var got payload
+			odize.AssertNoError(t, json.Unmarshal(data, &got))
+			odize.AssertTrue(t, got.Tags.Equal(NewSet("y", "x")))
+		}).
+		Test("should round trip as a struct field held by value", func(t *testing.T) {
+			type payload struct {
+				Tags Set[string] `json:"tags"`
+			}
+
+			data, err := json.Marshal(payload{Tags: *NewSet("x", "y")})
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"tags":["x","y"]}`, string(data))
+
+			data, err = json.Marshal(payload{})
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, `{"tags":[]}`, string(data))
+
+			var got payload
+			odize.AssertNoError(t, json.Unmarshal([]byte(`{"tags":["y","x","y"]}`), &got))
+			odize.AssertEqual(t, []string{"y", "x"}, got.Tags.Slice())
+		}).
+		Test("should treat null as a no-op", func(t *testing.T) {
+			s := NewSet(1, 2)
+			odize.AssertNoError(t, json.Unmarshal([]byte(`null`), s))
+
+			odize.AssertEqual(t, []int{1, 2}, s.Slice())
+		}).
+		Test("should return an error for invalid json", func(t *testing.T) {
+			var s Set[int]
+			odize.AssertError(t, json.Unmarshal([]byte(`{"a":1}`), &s))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleSet() {
+	s := NewSet("go", "rust", "go")
+	s.Add("zig")
+	s.Remove("rust")
+
+	fmt.Println(s, s.Has("go"), s.Len())
+	// Output: [go zig] true 2
+}
+
+func ExampleSet_Union() {
+	a := NewSet(1, 2, 3)
+	b := NewSet(3, 4)
+
+	fmt.Println(a.Union(b), a.Intersection(b), a.Difference(b), a.SymmetricDifference(b))
+	// Output: [1 2 3 4] [3] [1 2] [1 2 4]
+}
@@ -1,28 +1,21 @@
 package mewl
 
+import "iter"
+
 // Filter - return a new list of elements that return true on the predicate func.
+// The list is not modified, see FilterInPlace to filter without allocating.
 func Filter[T any](list []T, fn PredicateFunc[T]) []T {
-	var result []T
-	for _, item := range list {
-		if fn(item) {
-			result = append(result, item)
-		}
-	}
-
-	return result
+	return SeqCollect(SeqFilter(SeqFromSlice(list), fn))
 }
 
 // Map - creates a new array populated with the results of calling a provided function on every element in the calling array.
+// The list is not modified.
 func Map[T comparable, K any](list []T, fn MapperFunc[T, K]) []K {
-	var result []K
-
-	for _, item := range list {
-		result = append(result, fn(item))
-	}
-	return result
+	return SeqCollect(SeqMap(SeqFromSlice(list), fn))
 }
 
 // ForEach - iterates over the list and invokes the function on the element.
+// The function receives the list itself, writes through that argument modify the list.
 func ForEach[T comparable](list []T, fn CallbackSliceFunc[T]) {
 	for index, item := range list {
 		fn(item, index, list)
@@ -30,154 +23,139 @@ func ForEach[T comparable](list []T, fn CallbackSliceFunc[T]) {
 }
 
 // Unique - return unique items from a provided list
+// The result is a new slice, the list is not modified.
 func Unique[T comparable](list []T) []T {
-	cache := make(map[T]struct{}, 0)
-	var result []T
-
-	for _, item := range list {
-		if _, ok := cache[item]; ok {
-			continue
-		}
-
-		result = append(result, item)
-		cache[item] = struct{}{}
-	}
-	return result
+	return SeqCollect(SeqUnique(SeqFromSlice(list)))
 }
 
 // Union - merges two lists into a slice with no duplicates composed of the elements of each list.
+// The result is a new slice, none of the lists are modified.
 func Union[T comparable](lists ...[]T) []T {
-	cache := make(map[T]struct{}, 0)
-	var result []T
-
+	seqs := make([]iter.Seq[T], 0, len(lists))
 	for _, list := range lists {
-		for _, item := range list {
-			if _, ok := cache[item]; ok {
-				continue
-			}
-
-			result = append(result, item)
-			cache[item] = struct{}{}
-		}
+		seqs = append(seqs, SeqFromSlice(list))
 	}
 
-	return result
+	return SeqCollect(SeqUnion(seqs...))
 }
 
 // Find - returns the first element in the provided array that satisfies the provided testing function.
 // If item is not found return nil value.
 func Find[T any](list []T, fn PredicateSliceFunc[T]) (T, bool) {
-	for index, item := range list {
-		if fn(item, index, list) {
-			return item, true
-		}
-	}
-
-	var nilValue T
-	return nilValue, false
+	return SeqFind(SeqFromSlice(list), seqIndexed(list, fn))
 }
 
 // Every - tests whether all elements in the array pass the test implemented by the provided function.
 func Every[T any](list []T, fn PredicateSliceFunc[T]) bool {
-	for index, item := range list {
-		if !fn(item, index, list) {
-			return false
-		}
-	}
-
-	return true
+	return SeqEvery(SeqFromSlice(list), seqIndexed(list, fn))
 }
 
 // Reduce - executes a user-supplied "reducer" callback function on each element of the array,
 // in order, passing in the return value from the calculation on the preceding element.
 // The final result of running the reducer across all elements of the array is a single value
 func Reduce[T any](list []T, fn func(prev T, next T) T) ComposeFunc[T] {
-	return func(initVal T) T {
-		result := initVal
-		for _, item := range list {
-			result = fn(result, item)
-		}
-		return result
-	}
+	return SeqReduce(SeqFromSlice(list), fn)
 }
 
-// Reverse - return slice in reverse order
+// Reverse - return a new slice with the elements in reverse order.
+// The list is not modified, see ReverseInPlace to reverse without allocating.
 func Reverse[T comparable](list []T) []T {
+	if list == nil {
+		return nil
+	}
 
+	result := make([]T, len(list))
+	for i, item := range list {
+		result[len(list)-1-i] = item
+	}
+	return result
+}
+
+// ReverseInPlace - reverses the order of the elements within the list and returns it.
+// The list is modified, the result shares its backing array.
+func ReverseInPlace[T any](list []T) []T {
 	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
 		list[i], list[j] = list[j], list[i]
 	}
 	return list
 }
 
-// Chunk - creates a new nested slice with slice elements chunked
-func Chunk[T any](list []T, chunkSize int) [][]T {
-	var result [][]T
-	if len(list) <= chunkSize {
-		result = append(result, list)
-		return result
+// FilterInPlace - keeps the elements that return true on the predicate func, compacted to the front of the list.
+// The list is modified and the result shares its backing array, elements after the result's length are set to the zero value.
+func FilterInPlace[T any](list []T, fn PredicateFunc[T]) []T {
+	kept := 0
+	for _, item := range list {
+		if fn(item) {
+			list[kept] = item
+			kept++
+		}
 	}
-	for len(list) > chunkSize {
-		result = append(result, list[:chunkSize])
-		list = list[chunkSize:]
+
+	clear(list[kept:])
+	return list[:kept]
+}
+
+// Chunk - creates a new nested slice with slice elements chunked.
+// Each chunk is a copy, writing to a chunk does not modify the list.
+// A chunkSize less than 1 is treated as 1. An empty list returns a single empty chunk.
+func Chunk[T any](list []T, chunkSize int) [][]T {
+	if len(list) == 0 {
+		return [][]T{list[:0:0]}
 	}
-	result = append(result, list)
-	return result
+	return SeqCollect(SeqChunk(SeqFromSlice(list), chunkSize))
+}
+
+// Flatten - creates a new slice of the elements of every list in order, the reverse of Chunk.
+// The lists are not modified.
+func Flatten[T any](lists [][]T) []T {
+	return SeqCollect(SeqFlatten(SeqFromSlice(lists)))
+}
+
+// FlatMap - creates a new slice of the elements returned by calling the function on every element, in order.
+// The list is not modified.
+func FlatMap[T any, K any](list []T, fn func(item T) []K) []K {
+	return SeqCollect(SeqFlatten(SeqMap(SeqFromSlice(list), fn)))
+}
+
+// FlattenDepth - creates a new slice with nested []any flattened up to depth levels.
+// A depth less than 0 flattens every level, other slice types are kept as elements. The list is not modified.
+func FlattenDepth(list []any, depth int) []any {
+	return SeqCollect(SeqFlattenDepth(SeqFromSlice(list), depth))
 }
 
 // Difference - Creates an array of array values not included in the other given arrays.
+// Values are returned in the order they first appear. The result is a new slice, none of the lists are modified.
 func Difference[T comparable](lists ...[]T) []T {
-	exists := make(map[T]struct{}, 0)
-	diff := make(map[T]struct{}, 0)
+	exists := NewSet[T]()
+	diff := NewSet[T]()
 
 	for _, list := range lists {
-
 		for _, item := range list {
-
-			if _, ok := exists[item]; ok {
+			if exists.Has(item) {
 				continue
 			}
 
-			if _, ok := diff[item]; ok {
-				exists[item] = struct{}{}
-				delete(diff, item)
+			if diff.Has(item) {
+				exists.Add(item)
+				diff.Remove(item)
 				continue
 			}
 
-			diff[item] = struct{}{}
+			diff.Add(item)
 		}
 	}
-	var result []T
-	for key := range diff {
-		result = append(result, key)
-	}
-	return result
+
+	return diff.Slice()
 }
 
 // Without - Creates an array excluding all given values
+// The list is not modified, with no values to omit a copy of the list is returned.
 func Without[T comparable](list []T, omit ...T) []T {
-	var result []T
-	for _, item := range list {
-		for _, o := range omit {
-			if item == o {
-				continue
-			}
-
-			result = append(result, item)
-		}
-	}
-	return result
+	return SeqCollect(SeqWithout(SeqFromSlice(list), omit...))
 }
 
 // Some - Checks if predicate returns truthy for any element of a list.
 // Iteration is stopped once predicate returns truthy
 func Some[T any](list []T, fn PredicateSliceFunc[T]) bool {
-	result := false
-
-	for index, item := range list {
-		if fn(item, index, list) {
-			return true
-		}
-	}
-	return result
+	return SeqSome(SeqFromSlice(list), seqIndexed(list, fn))
 }
@@ -0,0 +1,177 @@
+package mewl
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+// aliasCase - calls a helper and returns every slice in its result.
+type aliasCase struct {
+	name string
+	call func(list []int) [][]int
+}
+
+func isOdd(item int) bool { return item%2 != 0 }
+
+func aliasCases() []aliasCase {
+	return []aliasCase{
+		{"Filter", func(list []int) [][]int { return [][]int{Filter(list, isOdd)} }},
+		{"Map", func(list []int) [][]int { return [][]int{Map(list, func(item int) int { return item })} }},
+		{"Unique", func(list []int) [][]int { return [][]int{Unique(list)} }},
+		{"Union", func(list []int) [][]int { return [][]int{Union(list, []int{9})} }},
+		{"Reverse", func(list []int) [][]int { return [][]int{Reverse(list)} }},
+		{"Chunk", func(list []int) [][]int { return Chunk(list, 2) }},
+		{"Flatten", func(list []int) [][]int { return [][]int{Flatten([][]int{list, list})} }},
+		{"FlatMap", func(list []int) [][]int {
+			return [][]int{FlatMap(list, func(item int) []int { return []int{item} })}
+		}},
+		{"Window", func(list []int) [][]int { return Window(list, 3, 1) }},
+		{"Scan", func(list []int) [][]int { return [][]int{Scan(list, 0, func(acc int, item int) int { return item })} }},
+		{"RunLengthDecode", func(list []int) [][]int { return [][]int{RunLengthDecode(RunLengthEncode(list))} }},
+		{"Difference", func(list []int) [][]int { return [][]int{Difference(list, []int{9})} }},
+		{"Without", func(list []int) [][]int { return [][]int{Without(list, 9)} }},
+		{"MapErr", func(list []int) [][]int {
+			got, _ := MapErr(list, func(item int) (int, error) { return item, nil })
+			return [][]int{got}
+		}},
+		{"FilterErr", func(list []int) [][]int {
+			got, _ := FilterErr(list, func(item int) (bool, error) { return isOdd(item), nil })
+			return [][]int{got}
+		}},
+		{"GroupBy", func(list []int) [][]int { return MapValues(GroupBy(list, isOdd)) }},
+		{"Partition", func(list []int) [][]int {
+			matched, rest := Partition(list, isOdd)
+			return [][]int{matched, rest}
+		}},
+		{"SortBy", func(list []int) [][]int {
+			return [][]int{SortBy(list, func(item int) int { return -item })}
+		}},
+		{"SortByDesc", func(list []int) [][]int {
+			return [][]int{SortByDesc(list, func(item int) int { return item })}
+		}},
+		{"TopK", func(list []int) [][]int {
+			return [][]int{TopK(list, 3, CompareBy(func(item int) int { return -item }))}
+		}},
+		{"Collection", func(list []int) [][]int { return [][]int{NewCollection(list).Slice()} }},
+	}
+}
+
+func TestSliceHelpers_aliasing(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	for _, c := range aliasCases() {
+		group.Test(fmt.Sprintf("%s should not modify the list", c.name), func(t *testing.T) {
+			list := []int{5, 3, 8, 1, 8, 2}
+			original := slices.Clone(list)
+
+			_ = c.call(list)
+
+			odize.AssertEqual(t, original, list)
+		})
+
+		group.Test(fmt.Sprintf("%s should not share memory with the list", c.name), func(t *testing.T) {
+			list := []int{5, 3, 8, 1, 8, 2}
+			original := slices.Clone(list)
+
+			for _, result := range c.call(list) {
+				for i := range result {
+					result[i] = -1
+				}
+			}
+
+			odize.AssertEqual(t, original, list)
+		})
+
+		group.Test(fmt.Sprintf("%s should not modify the list through spare capacity", c.name), func(t *testing.T) {
+			backing := []int{5, 3, 8, 1, 8, 2, 7, 7}
+			list := backing[:6]
+
+			for _, result := range c.call(list) {
+				_ = append(result, -1, -1)
+			}
+
+			odize.AssertEqual(t, []int{5, 3, 8, 1, 8, 2, 7, 7}, backing)
+		})
+	}
+
+	err := group.Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestReverseInPlace(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should reverse the list", func(t *testing.T) {
+			list := []int{1, 2, 3, 4}
+			got := ReverseInPlace(list)
+
+			odize.AssertEqual(t, []int{4, 3, 2, 1}, got)
+			odize.AssertEqual(t, []int{4, 3, 2, 1}, list)
+			odize.AssertTrue(t, &got[0] == &list[0])
+		}).
+		Test("should handle odd lengths and empty lists", func(t *testing.T) {
+			odize.AssertEqual(t, []int{3, 2, 1}, ReverseInPlace([]int{1, 2, 3}))
+			odize.AssertEqual(t, []int{}, ReverseInPlace([]int{}))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestFilterInPlace(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should compact kept elements to the front", func(t *testing.T) {
+			list := []int{1, 2, 3, 4, 5}
+			got := FilterInPlace(list, isOdd)
+
+			odize.AssertEqual(t, []int{1, 3, 5}, got)
+			odize.AssertTrue(t, &got[0] == &list[0])
+		}).
+		Test("should zero the elements after the result", func(t *testing.T) {
+			a, b, c := 1, 2, 3
+			list := []*int{&a, &b, &c}
+			got := FilterInPlace(list, func(item *int) bool { return *item == 2 })
+
+			odize.AssertEqual(t, 1, len(got))
+			odize.AssertEqual(t, 2, *got[0])
+			odize.AssertTrue(t, list[1] == nil && list[2] == nil)
+		}).
+		Test("should return an empty list when nothing matches", func(t *testing.T) {
+			got := FilterInPlace([]int{2, 4}, isOdd)
+
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestReverse_does_not_modify_list(t *testing.T) {
+	list := []int{1, 2, 3}
+	got := Reverse(list)
+
+	odize.AssertEqual(t, []int{3, 2, 1}, got)
+	odize.AssertEqual(t, []int{1, 2, 3}, list)
+}
+
+func ExampleReverseInPlace() {
+	list := []int{1, 2, 3}
+	ReverseInPlace(list)
+
+	fmt.Println(list)
+	// Output: [3 2 1]
+}
+
+func ExampleFilterInPlace() {
+	list := []int{1, 2, 3, 4, 5}
+	list = FilterInPlace(list, func(item int) bool {
+		return item > 2
+	})
+
+	fmt.Println(list)
+	// Output: [3 4 5]
+}
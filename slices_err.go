@@ -0,0 +1,127 @@
+package mewl
+
+import "errors"
+
+// MapErr - creates a new array populated with the results of calling a fallible function on every element.
+// The list is not modified.
+// By default it stops at the first error and returns nil results.
+// With ErrOptCollectAll every element is mapped, failed elements have the nil value in the results.
+// Errors wrap IndexError.
+func MapErr[T any, K any](list []T, fn func(item T) (K, error), opts ...ErrOpts) ([]K, error) {
+	config := newErrConfig(opts)
+
+	var result []K
+	var errs []error
+	for index, item := range list {
+		value, err := fn(item)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: index, Err: err})
+			if !config.collectAll {
+				return nil, errors.Join(errs...)
+			}
+		}
+		result = append(result, value)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// FilterErr - return a new list of elements that return true on a fallible predicate func.
+// The list is not modified.
+// By default it stops at the first error and returns a nil list.
+// With ErrOptCollectAll every element is tested, failed elements are left out of the list.
+// Errors wrap IndexError.
+func FilterErr[T any](list []T, fn func(item T) (bool, error), opts ...ErrOpts) ([]T, error) {
+	config := newErrConfig(opts)
+
+	var result []T
+	var errs []error
+	for index, item := range list {
+		ok, err := fn(item)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: index, Err: err})
+			if !config.collectAll {
+				return nil, errors.Join(errs...)
+			}
+			continue
+		}
+
+		if ok {
+			result = append(result, item)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// ForEachErr - iterates over the list and invokes a fallible function on the element.
+// The function receives the list itself, writes through that argument modify the list.
+// By default it stops at the first error, with ErrOptCollectAll it invokes the function on every element.
+// Errors wrap IndexError.
+func ForEachErr[T any](list []T, fn func(item T, index int, slice []T) error, opts ...ErrOpts) error {
+	config := newErrConfig(opts)
+
+	var errs []error
+	for index, item := range list {
+		if err := fn(item, index, list); err != nil {
+			errs = append(errs, &IndexError{Index: index, Err: err})
+			if !config.collectAll {
+				break
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// FindErr - returns the first element that satisfies a fallible predicate func.
+// By default it stops at the first error and returns the nil value.
+// With ErrOptCollectAll elements that fail are skipped, the search continues and all errors are returned alongside the result.
+// Errors wrap IndexError.
+func FindErr[T any](list []T, fn func(item T) (bool, error), opts ...ErrOpts) (T, bool, error) {
+	config := newErrConfig(opts)
+
+	var nilValue T
+	var errs []error
+	for index, item := range list {
+		ok, err := fn(item)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: index, Err: err})
+			if !config.collectAll {
+				return nilValue, false, errors.Join(errs...)
+			}
+			continue
+		}
+
+		if ok {
+			return item, true, errors.Join(errs...)
+		}
+	}
+
+	return nilValue, false, errors.Join(errs...)
+}
+
+// ReduceErr - executes a fallible reducer on each element in order, passing in the return value from the preceding element.
+// By default it stops at the first error and returns the value reduced so far.
+// With ErrOptCollectAll elements that fail are skipped, keeping the preceding value.
+// Errors wrap IndexError.
+func ReduceErr[T any](list []T, fn func(prev T, next T) (T, error), opts ...ErrOpts) func(initVal T) (T, error) {
+	config := newErrConfig(opts)
+
+	return func(initVal T) (T, error) {
+		result := initVal
+		var errs []error
+		for index, item := range list {
+			next, err := fn(result, item)
+			if err != nil {
+				errs = append(errs, &IndexError{Index: index, Err: err})
+				if !config.collectAll {
+					return result, errors.Join(errs...)
+				}
+				continue
+			}
+			result = next
+		}
+		return result, errors.Join(errs...)
+	}
+}
@@ -0,0 +1,157 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestMapErr(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should map every element", func(t *testing.T) {
+			got, err := MapErr([]string{"1", "2"}, strconv.Atoi)
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []int{1, 2}, got)
+		}).
+		Test("should stop at first error", func(t *testing.T) {
+			calls := 0
+			got, err := MapErr([]string{"1", "x", "y"}, func(item string) (int, error) {
+				calls++
+				return strconv.Atoi(item)
+			})
+
+			odize.AssertTrue(t, errors.Is(err, strconv.ErrSyntax))
+			odize.AssertEqual(t, []int{1}, FailedIndices(err))
+			odize.AssertEqual(t, 2, calls)
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Test("should collect all errors", func(t *testing.T) {
+			got, err := MapErr([]string{"1", "x", "3", "y"}, strconv.Atoi, ErrOptCollectAll())
+
+			odize.AssertEqual(t, []int{1, 3}, FailedIndices(err))
+			odize.AssertEqual(t, []int{1, 0, 3, 0}, got)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExampleMapErr() {
+	got, err := MapErr([]string{"1", "x", "3"}, strconv.Atoi, ErrOptCollectAll())
+
+	fmt.Println(got)
+	fmt.Println(err)
+	// Output:
+	// [1 0 3]
+	// index 1: strconv.Atoi: parsing "x": invalid syntax
+}
+
+func TestFilterErr(t *testing.T) {
+	isEven := func(item string) (bool, error) {
+		i, err := strconv.Atoi(item)
+		return i%2 == 0, err
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should filter elements", func(t *testing.T) {
+			got, err := FilterErr([]string{"1", "2", "4"}, isEven)
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []string{"2", "4"}, got)
+		}).
+		Test("should stop at first error", func(t *testing.T) {
+			got, err := FilterErr([]string{"2", "x", "4"}, isEven)
+
+			odize.AssertEqual(t, []int{1}, FailedIndices(err))
+			odize.AssertEqual(t, 0, len(got))
+		}).
+		Test("should leave out failed elements when collecting all errors", func(t *testing.T) {
+			got, err := FilterErr([]string{"2", "x", "4", "y"}, isEven, ErrOptCollectAll())
+
+			odize.AssertEqual(t, []int{1, 3}, FailedIndices(err))
+			odize.AssertEqual(t, []string{"2", "4"}, got)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestForEachErr(t *testing.T) {
+	fail := func(item int, _ int, _ []int) error {
+		if item < 0 {
+			return fmt.Errorf("negative: %d", item)
+		}
+		return nil
+	}
+
+	err := ForEachErr([]int{1, -1, -2}, fail)
+	odize.AssertEqual(t, []int{1}, FailedIndices(err))
+
+	err = ForEachErr([]int{1, -1, -2}, fail, ErrOptCollectAll())
+	odize.AssertEqual(t, []int{1, 2}, FailedIndices(err))
+
+	odize.AssertNoError(t, ForEachErr([]int{1, 2}, fail))
+}
+
+func TestFindErr(t *testing.T) {
+	isTwo := func(item string) (bool, error) {
+		i, err := strconv.Atoi(item)
+		return i == 2, err
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should find element", func(t *testing.T) {
+			got, ok, err := FindErr([]string{"1", "2"}, isTwo)
+			odize.AssertNoError(t, err)
+
+			odize.AssertTrue(t, ok)
+			odize.AssertEqual(t, "2", got)
+		}).
+		Test("should stop at first error", func(t *testing.T) {
+			_, ok, err := FindErr([]string{"x", "2"}, isTwo)
+
+			odize.AssertFalse(t, ok)
+			odize.AssertEqual(t, []int{0}, FailedIndices(err))
+		}).
+		Test("should keep searching past errors when collecting all errors", func(t *testing.T) {
+			got, ok, err := FindErr([]string{"x", "2"}, isTwo, ErrOptCollectAll())
+
+			odize.AssertTrue(t, ok)
+			odize.AssertEqual(t, "2", got)
+			odize.AssertEqual(t, []int{0}, FailedIndices(err))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestReduceErr(t *testing.T) {
+	add := func(prev, next int) (int, error) {
+		if next < 0 {
+			return prev, fmt.Errorf("negative: %d", next)
+		}
+		return prev + next, nil
+	}
+
+	got, err := ReduceErr([]int{1, 2, 3}, add)(1)
+	odize.AssertNoError(t, err)
+	odize.AssertEqual(t, 7, got)
+
+	got, err = ReduceErr([]int{1, -2, 3}, add)(0)
+	odize.AssertEqual(t, []int{1}, FailedIndices(err))
+	odize.AssertEqual(t, 1, got)
+
+	got, err = ReduceErr([]int{1, -2, 3}, add, ErrOptCollectAll())(0)
+	odize.AssertEqual(t, []int{1}, FailedIndices(err))
+	odize.AssertEqual(t, 4, got)
+}
@@ -2,6 +2,7 @@ package mewl
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -478,6 +479,11 @@ func TestChunk_less_than_chunk_size(t *testing.T) {
 	odize.AssertEqual(t, [][]int{list}, got)
 }
 
+func TestChunk_empty(t *testing.T) {
+	odize.AssertEqual(t, [][]int{{}}, Chunk([]int{}, 2))
+	odize.AssertEqual(t, [][]int{nil}, Chunk[int](nil, 2))
+}
+
 func TestDifference(t *testing.T) {
 	list1 := []int{1, 2, 3}
 	list2 := []int{2, 3, 4}
@@ -495,6 +501,51 @@ func ExampleDifference() {
 	// Output: [1 4]
 }
 
+func TestDifference_first_seen_order(t *testing.T) {
+	list1 := []int{9, 1, 5, 3}
+	list2 := []int{3, 7, 2}
+	got := Difference(list1, list2)
+
+	odize.AssertEqual(t, []int{9, 1, 5, 7, 2}, got)
+}
+
+func TestDifference_large_input(t *testing.T) {
+	// a quadratic Difference takes minutes on this input, linear takes milliseconds
+	list := make([]int, 200_000)
+	for i := range list {
+		list[i] = i
+	}
+
+	var expected []int
+	odize.AssertEqual(t, expected, Difference(list, list))
+	odize.AssertEqual(t, []int{-1}, Difference(list, list, []int{-1}))
+}
+
+func BenchmarkDifference(b *testing.B) {
+	list := make([]int, 50_000)
+	for i := range list {
+		list[i] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Difference(list, list)
+	}
+}
+
+func BenchmarkUnique(b *testing.B) {
+	list := make([]int, 50_000)
+	for i := range list {
+		list[i] = i % 1_000
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Unique(list)
+	}
+}
+
 func TestDifference_no_difference(t *testing.T) {
 	list1 := []int{1, 2, 3}
 	list2 := []int{1, 2, 3}
@@ -541,6 +592,24 @@ func TestWithout(t *testing.T) {
 	odize.AssertEqual(t, []KeyVal{{Key: "foo", Value: "bar"}}, got)
 }
 
+func TestWithout_multiple_values(t *testing.T) {
+	list := []int{1, 2, 3, 4}
+
+	got := Without(list, 2, 4)
+
+	odize.AssertEqual(t, []int{1, 3}, got)
+}
+
+func TestWithout_no_values(t *testing.T) {
+	list := []int{1, 2, 2, 3}
+
+	got := Without(list)
+	got[0] = 9
+
+	odize.AssertEqual(t, []int{9, 2, 2, 3}, got)
+	odize.AssertEqual(t, []int{1, 2, 2, 3}, list)
+}
+
 func ExampleWithout() {
 	list := []KeyVal{
 		{Key: "foo", Value: "bar"},
@@ -596,3 +665,88 @@ func ExampleSome() {
 	fmt.Println(got)
 	// Output: true
 }
+
+func TestFlatten(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should flatten lists in order", func(t *testing.T) {
+			got := Flatten([][]int{{1, 2}, {}, {3}, nil, {4, 5}})
+
+			odize.AssertEqual(t, []int{1, 2, 3, 4, 5}, got)
+		}).
+		Test("should reverse Chunk", func(t *testing.T) {
+			list := []int{1, 2, 3, 4, 5, 6, 7}
+
+			odize.AssertEqual(t, list, Flatten(Chunk(list, 3)))
+		}).
+		Test("should return nil for no elements", func(t *testing.T) {
+			var expected []int
+
+			odize.AssertEqual(t, expected, Flatten([][]int{{}, nil}))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleFlatten() {
+	got := Flatten([][]string{{"a", "b"}, {"c"}})
+
+	fmt.Println(got)
+	// Output: [a b c]
+}
+
+func TestFlatMap(t *testing.T) {
+	got := FlatMap([]string{"a,b", "", "c"}, func(item string) []string {
+		if item == "" {
+			return nil
+		}
+		return strings.Split(item, ",")
+	})
+
+	odize.AssertEqual(t, []string{"a", "b", "c"}, got)
+}
+
+func ExampleFlatMap() {
+	got := FlatMap([]int{1, 2, 3}, func(item int) []int {
+		return []int{item, item * 10}
+	})
+
+	fmt.Println(got)
+	// Output: [1 10 2 20 3 30]
+}
+
+func TestFlattenDepth(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	list := []any{1, []any{2, []any{3, []any{4}}}, "5", []int{6}}
+
+	err := group.
+		Test("should flatten one level", func(t *testing.T) {
+			got := FlattenDepth(list, 1)
+
+			odize.AssertEqual(t, []any{1, 2, []any{3, []any{4}}, "5", []int{6}}, got)
+		}).
+		Test("should flatten up to depth", func(t *testing.T) {
+			got := FlattenDepth(list, 2)
+
+			odize.AssertEqual(t, []any{1, 2, 3, []any{4}, "5", []int{6}}, got)
+		}).
+		Test("should flatten every level when depth is negative", func(t *testing.T) {
+			got := FlattenDepth(list, -1)
+
+			odize.AssertEqual(t, []any{1, 2, 3, 4, "5", []int{6}}, got)
+		}).
+		Test("should not flatten when depth is zero", func(t *testing.T) {
+			odize.AssertEqual(t, list, FlattenDepth(list, 0))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleFlattenDepth() {
+	got := FlattenDepth([]any{1, []any{2, []any{3}}}, -1)
+
+	fmt.Println(got)
+	// Output: [1 2 3]
+}
@@ -0,0 +1,246 @@
+package mewl
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+// Comparator - returns a negative number when a sorts before b, a positive number when a sorts after b and zero when they are equal.
+type Comparator[T any] func(a, b T) int
+
+// CompareOpts - options for comparators built from a key func.
+type CompareOpts func(*compareConfig)
+
+type compareConfig struct {
+	// desc - if set to true, keys are compared in descending order.
+	desc bool
+	// empty - where zero keys, or nil keys for CompareByPtr, are placed. Negative is first, positive is last.
+	empty int
+}
+
+// CompareBy - returns a comparator ordering items by the key returned from the key func, in ascending order.
+func CompareBy[T any, K cmp.Ordered](key func(T) K, opts ...CompareOpts) Comparator[T] {
+	config := newCompareConfig(opts)
+
+	var zero K
+	return func(a, b T) int {
+		keyA, keyB := key(a), key(b)
+		if result, ok := config.compareEmpty(keyA == zero, keyB == zero); ok {
+			return result
+		}
+		return config.direction(cmp.Compare(keyA, keyB))
+	}
+}
+
+// CompareByPtr - returns a comparator ordering items by the value the key func points to, in ascending order.
+// Nil keys are placed first unless CompareOptZeroLast is set.
+func CompareByPtr[T any, K cmp.Ordered](key func(T) *K, opts ...CompareOpts) Comparator[T] {
+	config := newCompareConfig(append([]CompareOpts{CompareOptZeroFirst()}, opts...))
+
+	return func(a, b T) int {
+		keyA, keyB := key(a), key(b)
+		if result, ok := config.compareEmpty(keyA == nil, keyB == nil); ok {
+			return result
+		}
+		return config.direction(cmp.Compare(*keyA, *keyB))
+	}
+}
+
+// ThenBy - returns a comparator that uses next to break ties.
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if result := c(a, b); result != 0 {
+			return result
+		}
+		return next(a, b)
+	}
+}
+
+// Reverse - returns a comparator with the order reversed, including the placement of zero keys.
+// Use CompareOptDesc to reverse a key while keeping zero keys in place.
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// CompareOptDesc - compare keys in descending order. Zero keys keep the placement set by CompareOptZeroFirst or CompareOptZeroLast.
+func CompareOptDesc() CompareOpts {
+	return func(c *compareConfig) {
+		c.desc = true
+	}
+}
+
+// CompareOptZeroFirst - place zero keys, or nil keys for CompareByPtr, before all other keys.
+func CompareOptZeroFirst() CompareOpts {
+	return func(c *compareConfig) {
+		c.empty = -1
+	}
+}
+
+// CompareOptZeroLast - place zero keys, or nil keys for CompareByPtr, after all other keys.
+func CompareOptZeroLast() CompareOpts {
+	return func(c *compareConfig) {
+		c.empty = 1
+	}
+}
+
+func newCompareConfig(opts []CompareOpts) compareConfig {
+	config := compareConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// compareEmpty - orders empty keys when a placement is set, ok is false if the keys still need comparing.
+func (c compareConfig) compareEmpty(emptyA, emptyB bool) (int, bool) {
+	switch {
+	case emptyA && emptyB:
+		return 0, true
+	case c.empty == 0:
+		return 0, false
+	case emptyA:
+		return c.empty, true
+	case emptyB:
+		return -c.empty, true
+	}
+	return 0, false
+}
+
+func (c compareConfig) direction(result int) int {
+	if c.desc {
+		return -result
+	}
+	return result
+}
+
+// SortWith - returns a sorted copy of the list ordered by the comparator. The sort is stable and the list is not modified.
+func SortWith[T any](list []T, compare Comparator[T]) []T {
+	result := slices.Clone(list)
+	slices.SortStableFunc(result, compare)
+	return result
+}
+
+// SortBy - returns a copy of the list sorted by the key func in ascending order. The sort is stable and the list is not modified.
+func SortBy[T any, K cmp.Ordered](list []T, key func(T) K) []T {
+	return SortWith(list, CompareBy(key))
+}
+
+// SortByDesc - returns a copy of the list sorted by the key func in descending order. The sort is stable and the list is not modified.
+func SortByDesc[T any, K cmp.Ordered](list []T, key func(T) K) []T {
+	return SortWith(list, CompareBy(key, CompareOptDesc()))
+}
+
+// IsSortedBy - reports whether the list is sorted by the key func in ascending order.
+func IsSortedBy[T any, K cmp.Ordered](list []T, key func(T) K) bool {
+	return IsSortedWith(list, CompareBy(key))
+}
+
+// IsSortedWith - reports whether the list is ordered by the comparator.
+func IsSortedWith[T any](list []T, compare Comparator[T]) bool {
+	return slices.IsSortedFunc(list, compare)
+}
+
+// MinBy - returns the item with the smallest key, the first one wins a tie. Returns false if the list is empty.
+func MinBy[T any, K cmp.Ordered](list []T, key func(T) K) (T, bool) {
+	return bestBy(list, key, -1)
+}
+
+// MaxBy - returns the item with the largest key, the first one wins a tie. Returns false if the list is empty.
+func MaxBy[T any, K cmp.Ordered](list []T, key func(T) K) (T, bool) {
+	return bestBy(list, key, 1)
+}
+
+// bestBy - returns the first item whose key compares to every other key with the wanted sign.
+func bestBy[T any, K cmp.Ordered](list []T, key func(T) K, want int) (T, bool) {
+	var best T
+	if len(list) == 0 {
+		return best, false
+	}
+
+	best = list[0]
+	bestKey := key(best)
+	for _, item := range list[1:] {
+		itemKey := key(item)
+		if cmp.Compare(itemKey, bestKey) == want {
+			best, bestKey = item, itemKey
+		}
+	}
+	return best, true
+}
+
+// TopK - returns the first k items of the list as if it were sorted by the comparator, without sorting the whole list.
+// Ties keep their original order and the list is not modified. Reverse the comparator to select the last k items.
+func TopK[T any](list []T, k int, compare Comparator[T]) []T {
+	if k <= 0 || len(list) == 0 {
+		return nil
+	}
+	if k >= len(list) {
+		return SortWith(list, compare)
+	}
+
+	h := &topKHeap[T]{compare: compare}
+	for index, item := range list {
+		entry := topKEntry[T]{item: item, index: index}
+		if h.Len() < k {
+			heap.Push(h, entry)
+			continue
+		}
+
+		// the root is the worst item kept so far, a later item only replaces it when strictly better
+		if compare(item, h.entries[0].item) < 0 {
+			h.entries[0] = entry
+			heap.Fix(h, 0)
+		}
+	}
+
+	slices.SortFunc(h.entries, h.compareEntries)
+	result := make([]T, len(h.entries))
+	for i, entry := range h.entries {
+		result[i] = entry.item
+	}
+	return result
+}
+
+type topKEntry[T any] struct {
+	item  T
+	index int
+}
+
+// topKHeap - max heap keeping the worst of the selected items at the root.
+type topKHeap[T any] struct {
+	entries []topKEntry[T]
+	compare Comparator[T]
+}
+
+// compareEntries - orders entries by the comparator, then by their index in the list.
+func (h *topKHeap[T]) compareEntries(a, b topKEntry[T]) int {
+	if result := h.compare(a.item, b.item); result != 0 {
+		return result
+	}
+	return cmp.Compare(a.index, b.index)
+}
+
+func (h *topKHeap[T]) Len() int {
+	return len(h.entries)
+}
+
+func (h *topKHeap[T]) Less(i, j int) bool {
+	return h.compareEntries(h.entries[i], h.entries[j]) > 0
+}
+
+func (h *topKHeap[T]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+}
+
+func (h *topKHeap[T]) Push(x any) {
+	h.entries = append(h.entries, x.(topKEntry[T]))
+}
+
+func (h *topKHeap[T]) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
@@ -0,0 +1,238 @@
+package mewl
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+type sortPerson struct {
+	Name string
+	Age  int
+	Nick *string
+}
+
+func sortPeople() []sortPerson {
+	bo := "bo"
+	al := "al"
+	return []sortPerson{
+		{Name: "carol", Age: 30},
+		{Name: "bob", Age: 25, Nick: &bo},
+		{Name: "alice", Age: 30, Nick: &al},
+		{Name: "dave", Age: 0},
+		{Name: "erin", Age: 25},
+	}
+}
+
+func sortNames(list []sortPerson) []string {
+	result := make([]string, len(list))
+	for i, item := range list {
+		result[i] = item.Name
+	}
+	return result
+}
+
+func byAge(p sortPerson) int      { return p.Age }
+func byName(p sortPerson) string  { return p.Name }
+func byNick(p sortPerson) *string { return p.Nick }
+
+func hasNick(p sortPerson) int {
+	if p.Nick == nil {
+		return 0
+	}
+	return 1
+}
+
+func TestSortBy(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should sort ascending and keep ties in order", func(t *testing.T) {
+			got := SortBy(sortPeople(), byAge)
+
+			odize.AssertEqual(t, []string{"dave", "bob", "erin", "carol", "alice"}, sortNames(got))
+		}).
+		Test("SortByDesc should sort descending and keep ties in order", func(t *testing.T) {
+			got := SortByDesc(sortPeople(), byAge)
+
+			odize.AssertEqual(t, []string{"carol", "alice", "bob", "erin", "dave"}, sortNames(got))
+		}).
+		Test("should not modify the list", func(t *testing.T) {
+			list := sortPeople()
+			_ = SortBy(list, byName)
+			_ = SortByDesc(list, byName)
+			_ = SortWith(list, CompareBy(byName))
+
+			odize.AssertEqual(t, sortNames(sortPeople()), sortNames(list))
+		}).
+		Test("should return an empty list for an empty list", func(t *testing.T) {
+			odize.AssertEqual(t, 0, len(SortBy([]sortPerson{}, byAge)))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestComparator(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("ThenBy should break ties", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareBy(byAge).ThenBy(CompareBy(byName)))
+
+			odize.AssertEqual(t, []string{"dave", "bob", "erin", "alice", "carol"}, sortNames(got))
+		}).
+		Test("ThenBy should chain", func(t *testing.T) {
+			compare := CompareBy(hasNick).
+				ThenBy(CompareBy(byAge, CompareOptDesc())).
+				ThenBy(CompareBy(byName))
+			got := SortWith(sortPeople(), compare)
+
+			odize.AssertEqual(t, []string{"carol", "erin", "dave", "alice", "bob"}, sortNames(got))
+		}).
+		Test("Reverse should reverse the whole comparator", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareBy(byAge).ThenBy(CompareBy(byName)).Reverse())
+
+			odize.AssertEqual(t, []string{"carol", "alice", "erin", "bob", "dave"}, sortNames(got))
+		}).
+		Test("CompareOptZeroLast should place zero keys last", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareBy(byAge, CompareOptZeroLast()))
+
+			odize.AssertEqual(t, []string{"bob", "erin", "carol", "alice", "dave"}, sortNames(got))
+		}).
+		Test("CompareOptZeroLast should keep zero keys last when descending", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareBy(byAge, CompareOptDesc(), CompareOptZeroLast()))
+
+			odize.AssertEqual(t, []string{"carol", "alice", "bob", "erin", "dave"}, sortNames(got))
+		}).
+		Test("CompareOptZeroFirst should keep zero keys first when descending", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareBy(byAge, CompareOptDesc(), CompareOptZeroFirst()))
+
+			odize.AssertEqual(t, []string{"dave", "carol", "alice", "bob", "erin"}, sortNames(got))
+		}).
+		Test("CompareByPtr should place nil keys first by default", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareByPtr(byNick))
+
+			odize.AssertEqual(t, []string{"carol", "dave", "erin", "alice", "bob"}, sortNames(got))
+		}).
+		Test("CompareByPtr should place nil keys last with CompareOptZeroLast", func(t *testing.T) {
+			got := SortWith(sortPeople(), CompareByPtr(byNick, CompareOptZeroLast(), CompareOptDesc()))
+
+			odize.AssertEqual(t, []string{"bob", "alice", "carol", "dave", "erin"}, sortNames(got))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestIsSortedBy(t *testing.T) {
+	odize.AssertTrue(t, IsSortedBy(SortBy(sortPeople(), byAge), byAge))
+	odize.AssertFalse(t, IsSortedBy(sortPeople(), byAge))
+	odize.AssertTrue(t, IsSortedBy([]sortPerson{}, byAge))
+	odize.AssertTrue(t, IsSortedWith(SortByDesc(sortPeople(), byAge), CompareBy(byAge).Reverse()))
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("MinBy should return the first smallest item", func(t *testing.T) {
+			got, ok := MinBy(sortPeople(), func(p sortPerson) int { return p.Age % 30 })
+
+			odize.AssertTrue(t, ok)
+			odize.AssertEqual(t, "carol", got.Name)
+		}).
+		Test("MaxBy should return the first largest item", func(t *testing.T) {
+			got, ok := MaxBy(sortPeople(), byAge)
+
+			odize.AssertTrue(t, ok)
+			odize.AssertEqual(t, "carol", got.Name)
+		}).
+		Test("should return false for an empty list", func(t *testing.T) {
+			_, ok := MinBy([]sortPerson{}, byAge)
+			odize.AssertFalse(t, ok)
+
+			_, ok = MaxBy([]sortPerson{}, byAge)
+			odize.AssertFalse(t, ok)
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestTopK(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should return the first k items in sorted order", func(t *testing.T) {
+			got := TopK(sortPeople(), 3, CompareBy(byAge))
+
+			odize.AssertEqual(t, []string{"dave", "bob", "erin"}, sortNames(got))
+		}).
+		Test("should keep ties in their original order", func(t *testing.T) {
+			got := TopK(sortPeople(), 2, CompareBy(byAge).Reverse())
+
+			odize.AssertEqual(t, []string{"carol", "alice"}, sortNames(got))
+		}).
+		Test("should sort the whole list when k is larger than the list", func(t *testing.T) {
+			got := TopK(sortPeople(), 10, CompareBy(byName))
+
+			odize.AssertEqual(t, []string{"alice", "bob", "carol", "dave", "erin"}, sortNames(got))
+		}).
+		Test("should return nil when k is not positive", func(t *testing.T) {
+			odize.AssertTrue(t, TopK(sortPeople(), 0, CompareBy(byAge)) == nil)
+			odize.AssertTrue(t, TopK([]sortPerson{}, 2, CompareBy(byAge)) == nil)
+		}).
+		Test("should match a stable sort", func(t *testing.T) {
+			r := rand.New(rand.NewSource(7))
+			list := make([]int, 500)
+			for i := range list {
+				list[i] = r.Intn(50)
+			}
+			original := append([]int(nil), list...)
+
+			type indexed struct{ value, index int }
+			pairs := make([]indexed, len(list))
+			for i, value := range list {
+				pairs[i] = indexed{value: value, index: i}
+			}
+			compare := CompareBy(func(p indexed) int { return p.value })
+
+			for _, k := range []int{1, 7, 50, 499} {
+				odize.AssertEqual(t, SortWith(pairs, compare)[:k], TopK(pairs, k, compare))
+			}
+			odize.AssertEqual(t, original, list)
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleSortBy() {
+	got := SortBy([]string{"banana", "kiwi", "apple", "fig"}, func(item string) int {
+		return len(item)
+	})
+
+	fmt.Println(got)
+	// Output: [fig kiwi apple banana]
+}
+
+func ExampleCompareBy() {
+	people := []sortPerson{
+		{Name: "carol", Age: 30},
+		{Name: "bob", Age: 25},
+		{Name: "alice", Age: 30},
+	}
+
+	compare := CompareBy(func(p sortPerson) int { return p.Age }).
+		Reverse().
+		ThenBy(CompareBy(func(p sortPerson) string { return p.Name }))
+
+	fmt.Println(sortNames(SortWith(people, compare)))
+	// Output: [alice carol bob]
+}
+
+func ExampleTopK() {
+	got := TopK([]int{5, 1, 9, 3, 7}, 2, CompareBy(func(item int) int { return item }).Reverse())
+
+	fmt.Println(got)
+	// Output: [9 7]
+}
@@ -1,11 +1,16 @@
 package mewl
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Txn[T any] struct {
+	id       string
 	txnState TxnState[T]
 	errors   []error
 	steps    []TxnStep[T]
@@ -14,6 +19,22 @@ type Txn[T any] struct {
 	failFast bool
 	// verbose - if set to true, the transaction will log out the steps as they are run.
 	verbose bool
+	// outbox - if set, enqueued messages are saved once every step has succeeded and discarded on rollback.
+	outbox *txnOutbox[T]
+	// chaos - if set, faults are injected into handlers and rollback funcs.
+	chaos *txnChaos
+	// deadLetters - if set, failed rollbacks are stored for retry or manual resolution.
+	deadLetters DeadLetterStore[T]
+	// deadLettered - true if a failed rollback was stored during the last run.
+	deadLettered bool
+	// tracer - receives a span for the run and a child span for every step and rollback.
+	tracer Tracer
+	// lockManager - if set, the resource keys declared by steps are locked for the duration of the run.
+	lockManager LockManager
+	// lockTimeout - maximum time to wait for all locks to be acquired.
+	lockTimeout time.Duration
+	// timeline - if set, every handler and rollback func is recorded.
+	timeline *txnTimeline[T]
 }
 
 type TxnState[T any] struct {
@@ -26,14 +47,24 @@ type TxnFunc[T any] func(T) (T, error)
 type TxnStep[T any] struct {
 	handler  TxnFunc[T]
 	rollback TxnFunc[T]
+	// preview - optional func describing the step's effect, used by Plan.
+	preview func(T) string
+	// name - optional name of the step, used for tracing.
+	name string
+	// locks - optional func returning the resource keys the step locks.
+	locks func(T) []string
 }
 
 type TxnOpts[T any] func(*Txn[T])
 
+type TxnStepOpts[T any] func(*TxnStep[T])
+
 // NewTxn - creates a new transaction. Txn implements a basic saga pattern which manages state between steps and rollback.
 func NewTxn[T any](state T, opts ...TxnOpts[T]) *Txn[T] {
 
 	t := &Txn[T]{
+		id:     uuid.NewString(),
+		tracer: noopTracer{},
 		txnState: TxnState[T]{
 			state:       &state,
 			currentStep: 0,
@@ -47,10 +78,21 @@ func NewTxn[T any](state T, opts ...TxnOpts[T]) *Txn[T] {
 	return t
 }
 
+// ID - returns the transaction id, used to identify the transaction's dead letters.
+func (t *Txn[T]) ID() string {
+	return t.id
+}
+
 // Step - adds a step to the transaction workflow.
-// All steps must have a handler and a rollback func.
-func (t *Txn[T]) Step(handler TxnFunc[T], rollback TxnFunc[T]) *Txn[T] {
-	t.steps = append(t.steps, TxnStep[T]{handler: handler, rollback: rollback})
+// All steps must have a handler. The rollback func may be nil for steps with nothing to compensate, they are skipped on rollback.
+func (t *Txn[T]) Step(handler TxnFunc[T], rollback TxnFunc[T], opts ...TxnStepOpts[T]) *Txn[T] {
+	step := TxnStep[T]{handler: handler, rollback: rollback}
+
+	for _, opt := range opts {
+		opt(&step)
+	}
+
+	t.steps = append(t.steps, step)
 	return t
 }
 
@@ -59,8 +101,35 @@ func (t *Txn[T]) Step(handler TxnFunc[T], rollback TxnFunc[T]) *Txn[T] {
 
 // Errors caught within the steps and rollback funcs will be able to be unwrapped and inspected using Unwrap() []error.
 func (t *Txn[T]) Run() (T, error) {
+	return t.RunContext(context.Background())
+}
+
+// RunContext - runs the transaction, the context is used as the parent of the transaction's trace spans.
+// See Run.
+func (t *Txn[T]) RunContext(ctx context.Context) (T, error) {
 	var err error
 
+	ctx, span := t.tracer.Start(ctx, "txn")
+	defer span.End()
+	span.SetAttribute("txn.id", t.id)
+	span.SetAttribute("txn.steps", len(t.steps))
+	t.errors = nil
+	t.deadLettered = false
+	if t.chaos != nil {
+		t.chaos.start()
+	}
+	if t.timeline != nil {
+		t.timeline.start(t.id, *t.txnState.state)
+	}
+
+	release, err := t.acquireLocks(ctx, span)
+	if err != nil {
+		t.log(fmt.Sprintf("transaction failed: %s", err))
+		span.RecordError(err)
+		return *t.txnState.state, err
+	}
+	defer release()
+
 	t.log(fmt.Sprintf("starting transaction with %d steps", len(t.steps)))
 	for index, step := range t.steps {
 
@@ -68,56 +137,125 @@ func (t *Txn[T]) Run() (T, error) {
 		logStep := index + 1
 		t.log(fmt.Sprintf("step %d: executing", logStep))
 
-		*t.txnState.state, err = step.handler(*t.txnState.state)
+		_, stepSpan := t.startStepSpan(ctx, "step", index)
+		*t.txnState.state, err = t.invoke(step.handler, *t.txnState.state, "step", logStep)
 		if err != nil {
 			t.log(fmt.Sprintf("step %d execution failed: step %s, rolling back", logStep, err))
+			stepSpan.RecordError(err)
+			stepSpan.End()
 
 			errWithCtx := fmt.Errorf("step failed: step %d: %w", logStep, err)
 			t.errors = append(t.errors, errWithCtx)
 
-			if err := t.rollback(); err != nil {
-				// fail fast stops the rollback early and returns first error
-				t.errors = append(t.errors, err)
-			}
-
-			return *t.txnState.state, errors.Join(t.errors...)
+			return t.abort(ctx, span)
 		}
 
+		stepSpan.End()
 		t.log(fmt.Sprintf("step %d: complete", logStep))
 	}
 
+	if t.outbox != nil {
+		if err := t.outbox.save(t.txnState.state); err != nil {
+			t.log(fmt.Sprintf("commit failed: %s, rolling back", err))
+
+			t.errors = append(t.errors, fmt.Errorf("commit failed: %w", err))
+			return t.abort(ctx, span)
+		}
+	}
+
+	span.SetAttribute("txn.status", "committed")
 	return *t.txnState.state, nil
 }
 
+// abort - rolls back the transaction and returns the state along with all errors collected.
+func (t *Txn[T]) abort(ctx context.Context, span Span) (T, error) {
+	span.AddEvent("rollback", nil)
+	if err := t.rollback(ctx); err != nil {
+		// fail fast stops the rollback early and returns first error
+		t.errors = append(t.errors, err)
+	}
+
+	if t.outbox != nil {
+		t.outbox.discard(t.txnState.state)
+	}
+
+	if t.deadLettered {
+		t.errors = append(t.errors, ErrTxnCompensationPending)
+	}
+
+	err := errors.Join(t.errors...)
+	span.SetAttribute("txn.status", "rolled_back")
+	span.RecordError(err)
+
+	return *t.txnState.state, err
+}
+
 // rollback - rolls back the transaction.
 // If failFast is set to true, it will stop at the first error on a rollback handler, otherwise it will continue.
-func (t *Txn[T]) rollback() error {
+func (t *Txn[T]) rollback(ctx context.Context) error {
 	var err error
+	if len(t.steps) == 0 {
+		return nil
+	}
+
 	for i := t.txnState.currentStep; i >= 0; i-- {
 		logStep := i + 1
 		step := t.steps[i]
 
+		if step.rollback == nil {
+			t.log(fmt.Sprintf("rollback step %d: no rollback registered, skipping", logStep))
+			if t.timeline != nil {
+				t.timeline.skip("rollback", logStep, *t.txnState.state)
+			}
+			continue
+		}
+
 		t.log(fmt.Sprintf("rollback step %d: executing", logStep))
 
-		*t.txnState.state, err = step.rollback(*t.txnState.state)
+		_, span := t.startStepSpan(ctx, "rollback", i)
+		snapshot := *t.txnState.state
+		*t.txnState.state, err = t.invoke(step.rollback, *t.txnState.state, "rollback", logStep)
 		if err != nil {
 			t.log(fmt.Sprintf("rollback step %d: failed: %s", logStep, err))
+			span.SetAttribute("txn.compensation.status", "failed")
+			span.RecordError(err)
 
 			errWithCtx := fmt.Errorf("rollback failed: step %d: %w", logStep, err)
+			if dlErr := t.deadLetter(logStep, snapshot, err); dlErr != nil {
+				t.errors = append(t.errors, dlErr)
+			} else if t.deadLetters != nil {
+				span.SetAttribute("txn.compensation.status", "dead_lettered")
+			}
+
 			if t.failFast {
+				t.deadLetterSkipped(i, snapshot)
+				span.End()
 				return errWithCtx
 			}
 
 			// add it to the list, but continue with rollback
 			t.errors = append(t.errors, errWithCtx)
+		} else {
+			span.SetAttribute("txn.compensation.status", "complete")
 		}
 
+		span.End()
 		t.log(fmt.Sprintf("rollback step %d: complete", logStep))
 	}
 
 	return nil
 }
 
+// invoke - calls a handler or rollback func with the state, recording the result on the timeline if enabled.
+// kind is either "step" or "rollback".
+func (t *Txn[T]) invoke(fn TxnFunc[T], state T, kind string, logStep int) (T, error) {
+	result, err := t.invokeWithChaos(fn, state, kind, logStep)
+	if t.timeline != nil {
+		t.timeline.record(kind, logStep, state, result, err)
+	}
+	return result, err
+}
+
 func (t *Txn[T]) log(msg string) {
 	if !t.verbose {
 		return
@@ -126,6 +264,20 @@ func (t *Txn[T]) log(msg string) {
 	fmt.Println(msg)
 }
 
+// TxnStepOptPreview - describes the effect of the step, the description is included in the output of Plan.
+func TxnStepOptPreview[T any](fn func(T) string) TxnStepOpts[T] {
+	return func(s *TxnStep[T]) {
+		s.preview = fn
+	}
+}
+
+// TxnStepOptName - names the step, the name is used for tracing.
+func TxnStepOptName[T any](name string) TxnStepOpts[T] {
+	return func(s *TxnStep[T]) {
+		s.name = name
+	}
+}
+
 // TxnOptFailFast - if set to true, the transaction will stop at the first error.
 func TxnOptFailFast[T any]() TxnOpts[T] {
 	return func(t *Txn[T]) {
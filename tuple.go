@@ -0,0 +1,137 @@
+package mewl
+
+import "slices"
+
+// Pair - two values of possibly different types.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple - three values of possibly different types.
+type Triple[A any, B any, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// NewPair - creates a pair of the values.
+func NewPair[A any, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// NewTriple - creates a triple of the values.
+func NewTriple[A any, B any, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: first, Second: second, Third: third}
+}
+
+// Unpack - returns the values of the pair.
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// Unpack - returns the values of the triple.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+// ZipOpts - options for Zip, Zip3 and ZipWith.
+type ZipOpts func(*zipConfig)
+
+type zipConfig struct {
+	// pad - if set to true, the result is as long as the longest list and missing values are the zero value.
+	pad bool
+}
+
+// ZipOptTruncate - the result is as long as the shortest list, extra values are dropped. This is the default.
+func ZipOptTruncate() ZipOpts {
+	return func(c *zipConfig) {
+		c.pad = false
+	}
+}
+
+// ZipOptPad - the result is as long as the longest list, missing values are the zero value.
+func ZipOptPad() ZipOpts {
+	return func(c *zipConfig) {
+		c.pad = true
+	}
+}
+
+// zipLen - returns the length of the zipped result for lists of the given lengths.
+func zipLen(opts []ZipOpts, lengths ...int) int {
+	config := zipConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.pad {
+		return slices.Max(lengths)
+	}
+	return slices.Min(lengths)
+}
+
+// zipAt - returns the element at index, or the zero value if the list is too short.
+func zipAt[T any](list []T, index int) T {
+	if index < len(list) {
+		return list[index]
+	}
+
+	var zero T
+	return zero
+}
+
+// Zip - pairs the elements of both lists by index.
+// By default the result is as long as the shortest list, see ZipOptPad.
+func Zip[A any, B any](first []A, second []B, opts ...ZipOpts) []Pair[A, B] {
+	return ZipWith(first, second, NewPair[A, B], opts...)
+}
+
+// Zip3 - groups the elements of three lists by index.
+// By default the result is as long as the shortest list, see ZipOptPad.
+func Zip3[A any, B any, C any](first []A, second []B, third []C, opts ...ZipOpts) []Triple[A, B, C] {
+	result := make([]Triple[A, B, C], zipLen(opts, len(first), len(second), len(third)))
+	for i := range result {
+		result[i] = NewTriple(zipAt(first, i), zipAt(second, i), zipAt(third, i))
+	}
+	return result
+}
+
+// ZipWith - combines the elements of both lists by index using the function.
+// By default the result is as long as the shortest list, see ZipOptPad.
+func ZipWith[A any, B any, R any](first []A, second []B, fn func(a A, b B) R, opts ...ZipOpts) []R {
+	result := make([]R, zipLen(opts, len(first), len(second)))
+	for i := range result {
+		result[i] = fn(zipAt(first, i), zipAt(second, i))
+	}
+	return result
+}
+
+// Unzip - splits a list of pairs into a list of the first values and a list of the second values.
+func Unzip[A any, B any](pairs []Pair[A, B]) ([]A, []B) {
+	first := make([]A, len(pairs))
+	second := make([]B, len(pairs))
+	for i, pair := range pairs {
+		first[i], second[i] = pair.Unpack()
+	}
+	return first, second
+}
+
+// Unzip3 - splits a list of triples into three lists.
+func Unzip3[A any, B any, C any](triples []Triple[A, B, C]) ([]A, []B, []C) {
+	first := make([]A, len(triples))
+	second := make([]B, len(triples))
+	third := make([]C, len(triples))
+	for i, triple := range triples {
+		first[i], second[i], third[i] = triple.Unpack()
+	}
+	return first, second, third
+}
+
+// Enumerate - pairs every element of the list with its index.
+func Enumerate[T any](list []T) []Pair[int, T] {
+	result := make([]Pair[int, T], len(list))
+	for i, item := range list {
+		result[i] = NewPair(i, item)
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+package mewl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestPair(t *testing.T) {
+	first, second := NewPair("id", 7).Unpack()
+
+	odize.AssertEqual(t, "id", first)
+	odize.AssertEqual(t, 7, second)
+}
+
+func TestTriple(t *testing.T) {
+	first, second, third := NewTriple("id", 7, true).Unpack()
+
+	odize.AssertEqual(t, "id", first)
+	odize.AssertEqual(t, 7, second)
+	odize.AssertTrue(t, third)
+}
+
+func TestZip(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	ids := []string{"a", "b", "c"}
+	scores := []int{10, 20}
+
+	err := group.
+		Test("should truncate to the shorter list by default", func(t *testing.T) {
+			got := Zip(ids, scores)
+
+			odize.AssertEqual(t, []Pair[string, int]{NewPair("a", 10), NewPair("b", 20)}, got)
+		}).
+		Test("ZipOptTruncate should truncate to the shorter list", func(t *testing.T) {
+			got := Zip(scores, ids, ZipOptPad(), ZipOptTruncate())
+
+			odize.AssertEqual(t, []Pair[int, string]{NewPair(10, "a"), NewPair(20, "b")}, got)
+		}).
+		Test("ZipOptPad should pad with zero values", func(t *testing.T) {
+			got := Zip(ids, scores, ZipOptPad())
+
+			odize.AssertEqual(t, []Pair[string, int]{NewPair("a", 10), NewPair("b", 20), NewPair("c", 0)}, got)
+		}).
+		Test("should return an empty list when a list is empty", func(t *testing.T) {
+			odize.AssertEqual(t, 0, len(Zip(ids, []int{})))
+			odize.AssertEqual(t, 3, len(Zip(ids, []int{}, ZipOptPad())))
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestZip3(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	ids := []string{"a", "b", "c"}
+	scores := []int{10, 20}
+	active := []bool{true}
+
+	err := group.
+		Test("should truncate to the shortest list by default", func(t *testing.T) {
+			got := Zip3(ids, scores, active)
+
+			odize.AssertEqual(t, []Triple[string, int, bool]{NewTriple("a", 10, true)}, got)
+		}).
+		Test("ZipOptPad should pad to the longest list", func(t *testing.T) {
+			got := Zip3(ids, scores, active, ZipOptPad())
+
+			odize.AssertEqual(t, []Triple[string, int, bool]{
+				NewTriple("a", 10, true),
+				NewTriple("b", 20, false),
+				NewTriple("c", 0, false),
+			}, got)
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestZipWith(t *testing.T) {
+	add := func(a int, b int) int { return a + b }
+
+	odize.AssertEqual(t, []int{11, 22}, ZipWith([]int{1, 2, 3}, []int{10, 20}, add))
+	odize.AssertEqual(t, []int{11, 22, 3}, ZipWith([]int{1, 2, 3}, []int{10, 20}, add, ZipOptPad()))
+}
+
+func TestUnzip(t *testing.T) {
+	ids := []string{"a", "b"}
+	scores := []int{10, 20}
+
+	gotIDs, gotScores := Unzip(Zip(ids, scores))
+
+	odize.AssertEqual(t, ids, gotIDs)
+	odize.AssertEqual(t, scores, gotScores)
+}
+
+func TestUnzip3(t *testing.T) {
+	first, second, third := Unzip3([]Triple[string, int, bool]{
+		NewTriple("a", 1, true),
+		NewTriple("b", 2, false),
+	})
+
+	odize.AssertEqual(t, []string{"a", "b"}, first)
+	odize.AssertEqual(t, []int{1, 2}, second)
+	odize.AssertEqual(t, []bool{true, false}, third)
+}
+
+func TestEnumerate(t *testing.T) {
+	got := Enumerate([]string{"x", "y"})
+
+	odize.AssertEqual(t, []Pair[int, string]{NewPair(0, "x"), NewPair(1, "y")}, got)
+	odize.AssertEqual(t, 0, len(Enumerate([]string{})))
+}
+
+func ExampleZip() {
+	ids := []string{"a", "b", "c"}
+	scores := []int{10, 20}
+
+	fmt.Println(Zip(ids, scores))
+	fmt.Println(Zip(ids, scores, ZipOptPad()))
+	// Output:
+	// [{a 10} {b 20}]
+	// [{a 10} {b 20} {c 0}]
+}
+
+func ExampleZipWith() {
+	got := ZipWith([]string{"a", "b"}, []int{1, 2}, func(id string, score int) string {
+		return fmt.Sprintf("%s=%d", id, score)
+	})
+
+	fmt.Println(got)
+	// Output: [a=1 b=2]
+}
+
+func ExampleEnumerate() {
+	for _, pair := range Enumerate([]string{"x", "y"}) {
+		index, item := pair.Unpack()
+		fmt.Println(index, item)
+	}
+	// Output:
+	// 0 x
+	// 1 y
+}
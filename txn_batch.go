@@ -0,0 +1,195 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// ErrTxnBatchSkipped - returned for batch items that were not run because an earlier item failed.
+var ErrTxnBatchSkipped = errors.New("batch item skipped: batch stopped on failure")
+
+// TxnBatchResult - result of running the transaction for a single input.
+type TxnBatchResult[T any] struct {
+	// Index - position of the input within the batch.
+	Index int
+	// TxnID - id of the transaction run for the input, used to find its dead letters. Empty if the input was skipped.
+	TxnID  string
+	Input  T
+	Result T
+	Err    error
+	// Timeline - timeline of the input's run, nil unless TxnOptTimeline is set or if the input was skipped.
+	Timeline *TxnTimeline
+}
+
+// TxnBatchResults - results of a batch, in input order.
+type TxnBatchResults[T any] []TxnBatchResult[T]
+
+// TxnBatchSummary - aggregate counts of a batch.
+type TxnBatchSummary struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
+
+// Summary - returns aggregate counts of the batch results.
+func (r TxnBatchResults[T]) Summary() TxnBatchSummary {
+	summary := TxnBatchSummary{Total: len(r)}
+
+	for _, item := range r {
+		switch {
+		case item.Err == nil:
+			summary.Succeeded++
+		case errors.Is(item.Err, ErrTxnBatchSkipped):
+			summary.Skipped++
+		default:
+			summary.Failed++
+		}
+	}
+
+	return summary
+}
+
+// Results - returns the result of each input, in input order.
+func (r TxnBatchResults[T]) Results() []T {
+	result := make([]T, len(r))
+	for index, item := range r {
+		result[index] = item.Result
+	}
+	return result
+}
+
+// Errors - returns the error of each input, in input order. Inputs that succeeded have a nil error.
+func (r TxnBatchResults[T]) Errors() []error {
+	result := make([]error, len(r))
+	for index, item := range r {
+		result[index] = item.Err
+	}
+	return result
+}
+
+type txnBatchConfig struct {
+	// stopOnFailure - if set to true, inputs not yet started are skipped once an input fails.
+	stopOnFailure bool
+}
+
+type TxnBatchOpts func(*txnBatchConfig)
+
+// RunBatch - runs the transaction once per input, using the input as the initial state.
+// Up to concurrency transactions are run at the same time, each with its own state and the same steps and options.
+// By default every input is run regardless of failures, see TxnBatchOptStopOnFailure.
+func (t *Txn[T]) RunBatch(inputs []T, concurrency int, opts ...TxnBatchOpts) TxnBatchResults[T] {
+	config := txnBatchConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make(TxnBatchResults[T], len(inputs))
+	indexes := make(chan int)
+	var stopped atomic.Bool
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				input := inputs[index]
+				if stopped.Load() {
+					results[index] = TxnBatchResult[T]{Index: index, Input: input, Result: input, Err: ErrTxnBatchSkipped}
+					continue
+				}
+
+				results[index] = t.runBatchInput(index, input)
+				if results[index].Err != nil && config.stopOnFailure {
+					stopped.Store(true)
+				}
+			}
+		}()
+	}
+
+	for index := range inputs {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// runBatchInput - runs a clone of the transaction for a single input.
+// A panic is recovered and returned as the input's error, so it cannot crash the other workers.
+func (t *Txn[T]) runBatchInput(index int, input T) (result TxnBatchResult[T]) {
+	txn := t.clone(index, input)
+	result = TxnBatchResult[T]{Index: index, TxnID: txn.ID(), Input: input, Result: input}
+
+	defer func() {
+		if txn.timeline != nil {
+			timeline := txn.Timeline()
+			result.Timeline = &timeline
+		}
+
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		if panicErr, ok := r.(error); ok {
+			result.Err = fmt.Errorf("txn batch: input %d: panic: %w", index, panicErr)
+			return
+		}
+		result.Err = fmt.Errorf("txn batch: input %d: panic: %v", index, r)
+	}()
+
+	result.Result, result.Err = txn.Run()
+	return result
+}
+
+// clone - returns a new transaction with the same steps and options, starting from the provided state.
+// Chaos mode is seeded with the config's seed plus the batch index, so each input's faults are reproducible.
+func (t *Txn[T]) clone(index int, state T) *Txn[T] {
+	var timeline *txnTimeline[T]
+	if t.timeline != nil {
+		timeline = &txnTimeline[T]{}
+	}
+
+	var chaos *txnChaos
+	if t.chaos != nil {
+		config := t.chaos.config
+		config.Seed += int64(index)
+		chaos = newTxnChaos(config)
+	}
+
+	return &Txn[T]{
+		id: uuid.NewString(),
+		txnState: TxnState[T]{
+			state:       &state,
+			currentStep: 0,
+		},
+		steps:       t.steps,
+		failFast:    t.failFast,
+		verbose:     t.verbose,
+		outbox:      t.outbox,
+		chaos:       chaos,
+		deadLetters: t.deadLetters,
+		tracer:      t.tracer,
+		lockManager: t.lockManager,
+		lockTimeout: t.lockTimeout,
+		timeline:    timeline,
+	}
+}
+
+// TxnBatchOptStopOnFailure - once an input fails, inputs not yet started are skipped with ErrTxnBatchSkipped.
+func TxnBatchOptStopOnFailure() TxnBatchOpts {
+	return func(c *txnBatchConfig) {
+		c.stopOnFailure = true
+	}
+}
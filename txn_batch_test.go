@@ -0,0 +1,165 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxn_RunBatch(t *testing.T) {
+	newTxn := func() *Txn[int] {
+		return NewTxn(0).Step(
+			func(i int) (int, error) {
+				if i < 0 {
+					return i, fmt.Errorf("negative input: %d", i)
+				}
+				return i * 2, nil
+			},
+			func(i int) (int, error) {
+				return i, nil
+			},
+		)
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should return results in input order", func(t *testing.T) {
+			results := newTxn().RunBatch([]int{1, 2, 3, 4, 5}, 3)
+
+			odize.AssertEqual(t, []int{2, 4, 6, 8, 10}, results.Results())
+			odize.AssertEqual(t, []error{nil, nil, nil, nil, nil}, results.Errors())
+		}).
+		Test("should continue past failures by default", func(t *testing.T) {
+			results := newTxn().RunBatch([]int{1, -1, 3}, 2)
+
+			odize.AssertEqual(t, TxnBatchSummary{Total: 3, Succeeded: 2, Failed: 1}, results.Summary())
+			odize.AssertEqual(t, 1, results[1].Index)
+			odize.AssertEqual(t, "step failed: step 1: negative input: -1", results[1].Err.Error())
+		}).
+		Test("should skip remaining inputs when stopping on failure", func(t *testing.T) {
+			results := newTxn().RunBatch([]int{-1, 2, 3}, 1, TxnBatchOptStopOnFailure())
+
+			odize.AssertEqual(t, TxnBatchSummary{Total: 3, Failed: 1, Skipped: 2}, results.Summary())
+			odize.AssertTrue(t, errors.Is(results[2].Err, ErrTxnBatchSkipped))
+			odize.AssertEqual(t, 3, results[2].Result)
+		}).
+		Test("should not exceed concurrency", func(t *testing.T) {
+			var running atomic.Int32
+			var maxRunning atomic.Int32
+
+			txn := NewTxn(0).Step(
+				func(i int) (int, error) {
+					current := running.Add(1)
+					defer running.Add(-1)
+					for {
+						seen := maxRunning.Load()
+						if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+							break
+						}
+					}
+					return i, nil
+				},
+				func(i int) (int, error) {
+					return i, nil
+				},
+			)
+
+			results := txn.RunBatch(make([]int, 50), 4)
+
+			odize.AssertEqual(t, 50, results.Summary().Succeeded)
+			odize.AssertTrue(t, maxRunning.Load() <= 4)
+		}).
+		Test("should return a panicking input as its error", func(t *testing.T) {
+			txn := NewTxn(0).Step(
+				func(i int) (int, error) {
+					if i < 0 {
+						panic("negative input")
+					}
+					return i * 2, nil
+				},
+				func(i int) (int, error) {
+					return i, nil
+				},
+			)
+
+			results := txn.RunBatch([]int{1, -1, 3}, 2)
+
+			odize.AssertEqual(t, TxnBatchSummary{Total: 3, Succeeded: 2, Failed: 1}, results.Summary())
+			odize.AssertEqual(t, "txn batch: input 1: panic: negative input", results[1].Err.Error())
+			odize.AssertEqual(t, -1, results[1].Result)
+		}).
+		Test("should identify the transaction run for each input", func(t *testing.T) {
+			store := NewMemoryDeadLetterStore[int]()
+			txn := NewTxn(0, TxnOptDeadLetter(store)).
+				Step(
+					func(i int) (int, error) { return i, nil },
+					func(i int) (int, error) { return i, errors.New("rollback failure") },
+				).
+				Step(
+					func(i int) (int, error) { return i, fmt.Errorf("expected failure: %d", i) },
+					nil,
+				)
+
+			results := txn.RunBatch([]int{1, 2}, 2)
+
+			odize.AssertTrue(t, results[0].TxnID != results[1].TxnID)
+			entries, err := store.List()
+			odize.AssertNoError(t, err)
+			for _, item := range results {
+				odize.AssertTrue(t, errors.Is(item.Err, ErrTxnCompensationPending))
+				odize.AssertEqual(t, 1, len(Filter(entries, func(entry DeadLetter[int]) bool {
+					return entry.TxnID == item.TxnID
+				})))
+			}
+		}).
+		Test("should return the timeline of each input when enabled", func(t *testing.T) {
+			results := newTxn().RunBatch([]int{1, -1}, 2)
+			odize.AssertTrue(t, results[0].Timeline == nil)
+
+			txn := NewTxn(0, TxnOptTimeline[int]()).Step(
+				func(i int) (int, error) { return i * 2, nil },
+				nil,
+			)
+			results = txn.RunBatch([]int{1, 2}, 2)
+
+			for _, item := range results {
+				odize.AssertEqual(t, item.TxnID, item.Timeline.TxnID)
+				odize.AssertEqual(t, 1, len(item.Timeline.Events))
+			}
+			odize.AssertEqual(t, "4", string(results[1].Timeline.Events[0].Output))
+		}).
+		Test("should compose with filter", func(t *testing.T) {
+			results := newTxn().RunBatch([]int{1, -1, -2}, 2)
+
+			failed := Filter(results, func(item TxnBatchResult[int]) bool {
+				return item.Err != nil
+			})
+
+			odize.AssertEqual(t, []int{-1, -2}, Map(failed, func(item TxnBatchResult[int]) int {
+				return item.Input
+			}))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExampleTxn_RunBatch() {
+	txn := NewTxn(0).Step(
+		func(i int) (int, error) {
+			return i * 2, nil
+		},
+		func(i int) (int, error) {
+			return i, nil
+		},
+	)
+
+	results := txn.RunBatch([]int{1, 2, 3}, 2)
+
+	fmt.Println(results.Results(), results.Summary())
+	// Output: [2 4 6] {3 3 0 0}
+}
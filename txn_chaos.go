@@ -0,0 +1,120 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrChaosInjected - wrapped by every fault injected by chaos mode, use errors.Is to tell injected faults from real ones.
+var ErrChaosInjected = errors.New("chaos: injected fault")
+
+// TxnChaosConfig - configures the faults injected into a transaction by TxnOptChaos.
+// Rates are probabilities between 0 and 1.
+type TxnChaosConfig struct {
+	// Seed - seed for the random number generator. Every run starts from the seed, so runs with the same seed and steps inject the same faults.
+	// Under RunBatch the input at index i is run with Seed+i, reproduce its faults by running the transaction alone with that seed.
+	Seed int64
+	// HandlerFailureRate - probability of a step handler failing instead of being called.
+	HandlerFailureRate float64
+	// RollbackFailureRate - probability of a rollback func failing instead of being called.
+	RollbackFailureRate float64
+	// PanicRate - probability of a handler or rollback func panicking instead of being called.
+	PanicRate float64
+	// MaxLatency - upper bound of random latency added before each handler and rollback func.
+	MaxLatency time.Duration
+}
+
+type txnChaos struct {
+	config TxnChaosConfig
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func newTxnChaos(config TxnChaosConfig) *txnChaos {
+	c := &txnChaos{config: config}
+	c.start()
+	return c
+}
+
+// start - restarts the random number generator from the seed, called at the start of every run.
+func (c *txnChaos) start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// #nosec G404 -- reproducible faults need a seeded, non cryptographic rng
+	c.rng = rand.New(rand.NewSource(c.config.Seed))
+}
+
+// inject - decides which faults to inject for a single invocation.
+func (c *txnChaos) inject(failureRate float64) (latency time.Duration, panics bool, fails bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.config.MaxLatency > 0 {
+		latency = time.Duration(c.rng.Int63n(int64(c.config.MaxLatency)))
+	}
+
+	panics = c.rng.Float64() < c.config.PanicRate
+	fails = c.rng.Float64() < failureRate
+
+	return latency, panics, fails
+}
+
+// invokeWithChaos - calls fn with the state, injecting faults first if chaos mode is enabled.
+// Injected panics are recovered and returned as errors, so they are compensated like any other failure.
+// kind is either "step" or "rollback".
+func (t *Txn[T]) invokeWithChaos(fn TxnFunc[T], state T, kind string, logStep int) (result T, err error) {
+	if t.chaos == nil {
+		return fn(state)
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		injected, ok := r.(error)
+		if !ok || !errors.Is(injected, ErrChaosInjected) {
+			panic(r)
+		}
+		result, err = state, injected
+	}()
+
+	failureRate := t.chaos.config.HandlerFailureRate
+	if kind == "rollback" {
+		failureRate = t.chaos.config.RollbackFailureRate
+	}
+
+	latency, panics, fails := t.chaos.inject(failureRate)
+	if latency > 0 {
+		t.log(fmt.Sprintf("chaos: %s %d: injecting %s latency", kind, logStep, latency))
+		time.Sleep(latency)
+	}
+
+	if panics {
+		t.log(fmt.Sprintf("chaos: %s %d: injecting panic", kind, logStep))
+		panic(fmt.Errorf("%w: panic", ErrChaosInjected))
+	}
+
+	if fails {
+		t.log(fmt.Sprintf("chaos: %s %d: injecting failure", kind, logStep))
+		return state, fmt.Errorf("%w: failure", ErrChaosInjected)
+	}
+
+	return fn(state)
+}
+
+// TxnOptChaos - opt in to chaos mode, injecting faults into handlers and rollback funcs.
+// Intended to exercise rollback paths in non production environments.
+// Injected errors and panics wrap ErrChaosInjected. Injected panics are recovered and the transaction is rolled back,
+// panics raised by the handlers and rollback funcs themselves are not recovered.
+func TxnOptChaos[T any](config TxnChaosConfig) TxnOpts[T] {
+	return func(t *Txn[T]) {
+		t.chaos = newTxnChaos(config)
+	}
+}
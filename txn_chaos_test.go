@@ -0,0 +1,184 @@
+package mewl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxnOptChaos(t *testing.T) {
+	type testState struct {
+		Name string
+	}
+
+	state := testState{Name: "hello"}
+	handlerCall := 0
+	rollbackCall := 0
+
+	newTxn := func(config TxnChaosConfig) *Txn[testState] {
+		return NewTxn(state, TxnOptChaos[testState](config)).Step(
+			func(ts testState) (testState, error) {
+				handlerCall++
+				ts.Name = "world"
+				return ts, nil
+			},
+			func(ts testState) (testState, error) {
+				rollbackCall++
+				ts.Name = "failed"
+				return ts, nil
+			},
+		)
+	}
+
+	group := odize.NewGroup(t, nil)
+	group.AfterEach(func() {
+		handlerCall = 0
+		rollbackCall = 0
+	})
+
+	err := group.
+		Test("should run normally with no faults configured", func(t *testing.T) {
+			result, err := newTxn(TxnChaosConfig{Seed: 1}).Run()
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, "world", result.Name)
+			odize.AssertEqual(t, 1, handlerCall)
+		}).
+		Test("should mark injected handler failures", func(t *testing.T) {
+			result, err := newTxn(TxnChaosConfig{Seed: 1, HandlerFailureRate: 1}).Run()
+			odize.AssertTrue(t, errors.Is(err, ErrChaosInjected))
+			odize.AssertEqual(t, "step failed: step 1: chaos: injected fault: failure", err.Error())
+
+			odize.AssertEqual(t, "failed", result.Name)
+			odize.AssertEqual(t, 0, handlerCall)
+			odize.AssertEqual(t, 1, rollbackCall)
+		}).
+		Test("should mark injected rollback failures", func(t *testing.T) {
+			txn := newTxn(TxnChaosConfig{Seed: 1, RollbackFailureRate: 1}).Step(
+				func(ts testState) (testState, error) {
+					return ts, errors.New("expected failure")
+				},
+				func(ts testState) (testState, error) {
+					return ts, nil
+				},
+			)
+
+			_, err := txn.Run()
+			odize.AssertTrue(t, errors.Is(err, ErrChaosInjected))
+			odize.AssertEqual(t, 0, rollbackCall)
+		}).
+		Test("should recover injected panics and roll back", func(t *testing.T) {
+			result, err := newTxn(TxnChaosConfig{Seed: 1, PanicRate: 1}).Run()
+
+			odize.AssertTrue(t, errors.Is(err, ErrChaosInjected))
+			odize.AssertTrue(t, strings.Contains(err.Error(), "step failed: step 1: chaos: injected fault: panic"))
+			odize.AssertTrue(t, strings.Contains(err.Error(), "rollback failed: step 1: chaos: injected fault: panic"))
+			odize.AssertEqual(t, "hello", result.Name)
+			odize.AssertEqual(t, 0, handlerCall)
+		}).
+		Test("should report injected panics per batch input", func(t *testing.T) {
+			results := newTxn(TxnChaosConfig{Seed: 1, PanicRate: 1}).RunBatch([]testState{state, state}, 2)
+
+			for _, err := range results.Errors() {
+				odize.AssertTrue(t, errors.Is(err, ErrChaosInjected))
+			}
+			odize.AssertEqual(t, 0, handlerCall)
+		}).
+		Test("should not recover panics raised by handlers", func(t *testing.T) {
+			var recovered any
+			func() {
+				defer func() {
+					recovered = recover()
+				}()
+				_, _ = NewTxn(state, TxnOptChaos[testState](TxnChaosConfig{Seed: 1})).Step(
+					func(ts testState) (testState, error) { panic("boom") },
+					func(ts testState) (testState, error) { return ts, nil },
+				).Run()
+			}()
+
+			odize.AssertEqual(t, "boom", recovered)
+		}).
+		Test("should inject the same faults for the same seed", func(t *testing.T) {
+			run := func() (int, error) {
+				calls := 0
+				txn := NewTxn(state, TxnOptChaos[testState](TxnChaosConfig{Seed: 42, HandlerFailureRate: 0.2}))
+				for i := 0; i < 20; i++ {
+					txn.Step(
+						func(ts testState) (testState, error) {
+							calls++
+							return ts, nil
+						},
+						func(ts testState) (testState, error) {
+							return ts, nil
+						},
+					)
+				}
+
+				_, err := txn.Run()
+				return calls, err
+			}
+
+			firstCalls, firstErr := run()
+			secondCalls, secondErr := run()
+
+			odize.AssertTrue(t, errors.Is(firstErr, ErrChaosInjected))
+			odize.AssertEqual(t, firstErr.Error(), secondErr.Error())
+			odize.AssertEqual(t, firstCalls, secondCalls)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestTxnOptChaos_reproducible(t *testing.T) {
+	newTxn := func(seed int64) *Txn[int] {
+		txn := NewTxn(0, TxnOptChaos[int](TxnChaosConfig{Seed: seed, HandlerFailureRate: 0.1}))
+		for i := 0; i < 20; i++ {
+			txn.Step(
+				func(state int) (int, error) { return state + 1, nil },
+				func(state int) (int, error) { return state - 1, nil },
+			)
+		}
+		return txn
+	}
+
+	errMessage := func(err error) string {
+		if err == nil {
+			return ""
+		}
+		return err.Error()
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should inject the same faults on every run of a transaction", func(t *testing.T) {
+			txn := newTxn(7)
+
+			_, firstErr := txn.Run()
+			_, secondErr := txn.Run()
+
+			odize.AssertTrue(t, errors.Is(firstErr, ErrChaosInjected))
+			odize.AssertEqual(t, errMessage(firstErr), errMessage(secondErr))
+		}).
+		Test("should seed each batch input with the seed plus its index", func(t *testing.T) {
+			inputs := make([]int, 16)
+			batch := func() []string {
+				return Map(newTxn(7).RunBatch(inputs, 4).Errors(), errMessage)
+			}
+
+			first := batch()
+			odize.AssertEqual(t, first, batch())
+
+			for index := range inputs {
+				_, err := newTxn(7 + int64(index)).Run()
+				odize.AssertEqual(t, first[index], errMessage(err))
+			}
+			odize.AssertTrue(t, Some(first, func(item string, _ int, _ []string) bool { return item != "" }))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,239 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTxnCompensationPending - returned by Run when a rollback failed and was stored as a dead letter.
+// The transaction is not finished until every dead letter is retried successfully or resolved manually.
+var ErrTxnCompensationPending = errors.New("compensation pending: rollback failed and was dead lettered")
+
+// ErrTxnCompensationSkipped - stored against the earlier steps of a fail fast rollback that stopped at a failed rollback.
+// Their rollback funcs were never called, retry or resolve them like any other dead letter.
+var ErrTxnCompensationSkipped = errors.New("compensation skipped: rollback stopped early by fail fast")
+
+// ErrDeadLetterNotFound - returned when a dead letter does not exist within the store.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// ErrDeadLetterResolved - returned when retrying a dead letter that has already been resolved.
+var ErrDeadLetterResolved = errors.New("dead letter already resolved")
+
+// DeadLetter - a failed rollback awaiting retry or manual resolution.
+type DeadLetter[T any] struct {
+	ID    string
+	TxnID string
+	// Step - step whose rollback failed, starting at 1.
+	Step int
+	// State - snapshot of the state passed to the rollback func, or to the failed rollback if this one was skipped.
+	State     T
+	Err       error
+	Resolved  bool
+	CreatedAt time.Time
+}
+
+// DeadLetterStore - stores failed rollbacks.
+type DeadLetterStore[T any] interface {
+	// Add - adds a new dead letter.
+	Add(entry DeadLetter[T]) error
+	// Get - returns the dead letter with the id, or ErrDeadLetterNotFound.
+	Get(id string) (DeadLetter[T], error)
+	// List - returns all dead letters, oldest first.
+	List() ([]DeadLetter[T], error)
+	// Update - replaces the dead letter with the same id, or returns ErrDeadLetterNotFound.
+	Update(entry DeadLetter[T]) error
+}
+
+// MemoryDeadLetterStore - in memory dead letter store, safe for concurrent use.
+type MemoryDeadLetterStore[T any] struct {
+	mu      sync.Mutex
+	entries []DeadLetter[T]
+}
+
+// NewMemoryDeadLetterStore - creates a new in memory dead letter store.
+func NewMemoryDeadLetterStore[T any]() *MemoryDeadLetterStore[T] {
+	return &MemoryDeadLetterStore[T]{}
+}
+
+// Add - adds a new dead letter.
+func (s *MemoryDeadLetterStore[T]) Add(entry DeadLetter[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+// Get - returns the dead letter with the id, or ErrDeadLetterNotFound.
+func (s *MemoryDeadLetterStore[T]) Get(id string) (DeadLetter[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := Find(s.entries, func(item DeadLetter[T], _ int, _ []DeadLetter[T]) bool {
+		return item.ID == id
+	})
+	if !ok {
+		return entry, fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+	}
+	return entry, nil
+}
+
+// List - returns all dead letters, oldest first.
+func (s *MemoryDeadLetterStore[T]) List() ([]DeadLetter[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]DeadLetter[T], len(s.entries))
+	copy(result, s.entries)
+	return result, nil
+}
+
+// Update - replaces the dead letter with the same id, or returns ErrDeadLetterNotFound.
+func (s *MemoryDeadLetterStore[T]) Update(entry DeadLetter[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for index, item := range s.entries {
+		if item.ID == entry.ID {
+			s.entries[index] = entry
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrDeadLetterNotFound, entry.ID)
+}
+
+// deadLetter - stores a failed rollback if a dead letter store has been configured.
+func (t *Txn[T]) deadLetter(logStep int, state T, err error) error {
+	if t.deadLetters == nil {
+		return nil
+	}
+
+	t.log(fmt.Sprintf("rollback step %d: dead lettered", logStep))
+	dlErr := t.deadLetters.Add(DeadLetter[T]{
+		ID:        uuid.NewString(),
+		TxnID:     t.id,
+		Step:      logStep,
+		State:     state,
+		Err:       err,
+		CreatedAt: time.Now(),
+	})
+	if dlErr != nil {
+		return fmt.Errorf("dead letter failed: step %d: %w", logStep, dlErr)
+	}
+
+	t.deadLettered = true
+	return nil
+}
+
+// deadLetterSkipped - stores the steps from logStep down to the first step, whose rollbacks were skipped by fail fast.
+// Steps without a rollback func have nothing to compensate and are not stored.
+// Their rollbacks are retried against the state passed to the rollback that failed.
+func (t *Txn[T]) deadLetterSkipped(logStep int, state T) {
+	for step := logStep; step >= 1; step-- {
+		if t.steps[step-1].rollback == nil {
+			continue
+		}
+
+		if err := t.deadLetter(step, state, ErrTxnCompensationSkipped); err != nil {
+			t.errors = append(t.errors, err)
+		}
+	}
+}
+
+// DeadLetters - returns the unresolved dead letters for this transaction.
+func (t *Txn[T]) DeadLetters() ([]DeadLetter[T], error) {
+	if t.deadLetters == nil {
+		return nil, nil
+	}
+
+	entries, err := t.deadLetters.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return Filter(entries, func(item DeadLetter[T]) bool {
+		return item.TxnID == t.id && !item.Resolved
+	}), nil
+}
+
+// Finished - reports whether every failed rollback of this transaction has been resolved.
+func (t *Txn[T]) Finished() (bool, error) {
+	entries, err := t.DeadLetters()
+	if err != nil {
+		return false, err
+	}
+
+	return len(entries) == 0, nil
+}
+
+// RetryDeadLetter - re-runs the rollback func of the dead lettered step against the stored state snapshot.
+// On success the dead letter is resolved, otherwise the error is recorded against it.
+// The dead letter may belong to another instance of the transaction as long as it has the same steps.
+// Resolved dead letters are not retried, ErrDeadLetterResolved is returned instead.
+func (t *Txn[T]) RetryDeadLetter(id string) (T, error) {
+	var nilValue T
+	if t.deadLetters == nil {
+		return nilValue, fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+	}
+
+	entry, err := t.deadLetters.Get(id)
+	if err != nil {
+		return nilValue, err
+	}
+
+	if entry.Resolved {
+		return entry.State, fmt.Errorf("%w: %s", ErrDeadLetterResolved, id)
+	}
+
+	if entry.Step < 1 || entry.Step > len(t.steps) {
+		return entry.State, fmt.Errorf("retry failed: step %d: step does not exist", entry.Step)
+	}
+
+	rollback := t.steps[entry.Step-1].rollback
+	if rollback == nil {
+		return entry.State, fmt.Errorf("retry failed: step %d: step has no rollback", entry.Step)
+	}
+
+	t.log(fmt.Sprintf("retry rollback step %d: executing", entry.Step))
+	state, err := rollback(entry.State)
+	if err != nil {
+		entry.Err = err
+		retryErr := fmt.Errorf("retry failed: step %d: %w", entry.Step, err)
+		if updateErr := t.deadLetters.Update(entry); updateErr != nil {
+			return state, errors.Join(retryErr, updateErr)
+		}
+		return state, retryErr
+	}
+
+	entry.Resolved = true
+	entry.Err = nil
+	return state, t.deadLetters.Update(entry)
+}
+
+// ResolveDeadLetter - marks a dead letter as manually resolved.
+func (t *Txn[T]) ResolveDeadLetter(id string) error {
+	if t.deadLetters == nil {
+		return fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+	}
+
+	entry, err := t.deadLetters.Get(id)
+	if err != nil {
+		return err
+	}
+
+	entry.Resolved = true
+	return t.deadLetters.Update(entry)
+}
+
+// TxnOptDeadLetter - stores failed rollbacks in the store, so they can be retried or manually resolved.
+// Run returns ErrTxnCompensationPending when a rollback has been dead lettered.
+// Combined with TxnOptFailFast, the steps whose rollbacks were skipped are dead lettered with ErrTxnCompensationSkipped.
+func TxnOptDeadLetter[T any](store DeadLetterStore[T]) TxnOpts[T] {
+	return func(t *Txn[T]) {
+		t.deadLetters = store
+	}
+}
@@ -0,0 +1,247 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxnOptDeadLetter(t *testing.T) {
+	type testState struct {
+		Name string
+	}
+
+	state := testState{Name: "hello"}
+	rollbackErr := fmt.Errorf("rollback failure")
+	rollbackFails := true
+
+	newTxn := func(store DeadLetterStore[testState]) *Txn[testState] {
+		return NewTxn(state, TxnOptDeadLetter(store)).
+			Step(
+				func(ts testState) (testState, error) {
+					ts.Name = "world"
+					return ts, nil
+				},
+				func(ts testState) (testState, error) {
+					if rollbackFails {
+						return ts, rollbackErr
+					}
+					ts.Name = "rolled back"
+					return ts, nil
+				},
+			).
+			Step(
+				func(ts testState) (testState, error) {
+					return ts, fmt.Errorf("expected failure")
+				},
+				func(ts testState) (testState, error) {
+					return ts, nil
+				},
+			)
+	}
+
+	group := odize.NewGroup(t, nil)
+	group.AfterEach(func() {
+		rollbackFails = true
+	})
+
+	err := group.
+		Test("should dead letter failed rollbacks", func(t *testing.T) {
+			store := NewMemoryDeadLetterStore[testState]()
+			txn := newTxn(store)
+
+			_, err := txn.Run()
+			odize.AssertTrue(t, errors.Is(err, ErrTxnCompensationPending))
+			odize.AssertTrue(t, errors.Is(err, rollbackErr))
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 1, len(entries))
+			odize.AssertEqual(t, 1, entries[0].Step)
+			odize.AssertEqual(t, txn.ID(), entries[0].TxnID)
+			odize.AssertEqual(t, "world", entries[0].State.Name)
+
+			finished, err := txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertFalse(t, finished)
+		}).
+		Test("should resolve dead letter on successful retry", func(t *testing.T) {
+			store := NewMemoryDeadLetterStore[testState]()
+			txn := newTxn(store)
+			_, _ = txn.Run()
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+
+			rollbackFails = false
+			result, err := txn.RetryDeadLetter(entries[0].ID)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, "rolled back", result.Name)
+
+			finished, err := txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertTrue(t, finished)
+		}).
+		Test("should keep dead letter on failed retry", func(t *testing.T) {
+			store := NewMemoryDeadLetterStore[testState]()
+			txn := newTxn(store)
+			_, _ = txn.Run()
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+
+			_, err = txn.RetryDeadLetter(entries[0].ID)
+			odize.AssertTrue(t, errors.Is(err, rollbackErr))
+
+			finished, err := txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertFalse(t, finished)
+		}).
+		Test("should finish once dead letter is manually resolved", func(t *testing.T) {
+			store := NewMemoryDeadLetterStore[testState]()
+			txn := newTxn(store)
+			_, _ = txn.Run()
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+
+			odize.AssertNoError(t, txn.ResolveDeadLetter(entries[0].ID))
+
+			finished, err := txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertTrue(t, finished)
+		}).
+		Test("should not report pending compensation from an earlier run", func(t *testing.T) {
+			txn := newTxn(NewMemoryDeadLetterStore[testState]())
+			_, err := txn.Run()
+			odize.AssertTrue(t, errors.Is(err, ErrTxnCompensationPending))
+
+			rollbackFails = false
+			_, err = txn.Run()
+			odize.AssertError(t, err)
+			odize.AssertFalse(t, errors.Is(err, ErrTxnCompensationPending))
+			odize.AssertFalse(t, errors.Is(err, rollbackErr))
+		}).
+		Test("should return not found for unknown dead letter", func(t *testing.T) {
+			txn := newTxn(NewMemoryDeadLetterStore[testState]())
+
+			err := txn.ResolveDeadLetter("missing")
+			odize.AssertTrue(t, errors.Is(err, ErrDeadLetterNotFound))
+		}).
+		Test("should not report pending compensation when rollbacks succeed", func(t *testing.T) {
+			rollbackFails = false
+			txn := newTxn(NewMemoryDeadLetterStore[testState]())
+
+			_, err := txn.Run()
+			odize.AssertFalse(t, errors.Is(err, ErrTxnCompensationPending))
+
+			finished, err := txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertTrue(t, finished)
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestTxnOptDeadLetter_fail_fast(t *testing.T) {
+	rollbackErr := errors.New("rollback failure")
+	firstRollbackCalls := 0
+
+	newTxn := func(store DeadLetterStore[int]) *Txn[int] {
+		return NewTxn(0, TxnOptDeadLetter(store), TxnOptFailFast[int]()).
+			Step(
+				func(state int) (int, error) { return state + 1, nil },
+				func(state int) (int, error) {
+					firstRollbackCalls++
+					return state - 1, nil
+				},
+			).
+			Step(
+				func(state int) (int, error) { return state + 1, nil },
+				func(state int) (int, error) { return state, rollbackErr },
+			).
+			Step(
+				func(state int) (int, error) { return state, errors.New("expected failure") },
+				func(state int) (int, error) { return state, nil },
+			)
+	}
+
+	group := odize.NewGroup(t, nil)
+	group.BeforeEach(func() {
+		firstRollbackCalls = 0
+	})
+
+	err := group.
+		Test("should dead letter the steps skipped by fail fast", func(t *testing.T) {
+			txn := newTxn(NewMemoryDeadLetterStore[int]())
+
+			_, err := txn.Run()
+			odize.AssertTrue(t, errors.Is(err, ErrTxnCompensationPending))
+			odize.AssertTrue(t, errors.Is(err, rollbackErr))
+			odize.AssertEqual(t, 0, firstRollbackCalls)
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 2, len(entries))
+			odize.AssertEqual(t, 2, entries[0].Step)
+			odize.AssertTrue(t, errors.Is(entries[0].Err, rollbackErr))
+			odize.AssertEqual(t, 1, entries[1].Step)
+			odize.AssertTrue(t, errors.Is(entries[1].Err, ErrTxnCompensationSkipped))
+			odize.AssertEqual(t, 2, entries[1].State)
+		}).
+		Test("should not finish until the skipped steps are compensated", func(t *testing.T) {
+			txn := newTxn(NewMemoryDeadLetterStore[int]())
+			_, _ = txn.Run()
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+			odize.AssertNoError(t, txn.ResolveDeadLetter(entries[0].ID))
+
+			finished, err := txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertFalse(t, finished)
+
+			_, err = txn.RetryDeadLetter(entries[1].ID)
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 1, firstRollbackCalls)
+
+			finished, err = txn.Finished()
+			odize.AssertNoError(t, err)
+			odize.AssertTrue(t, finished)
+		}).
+		Test("should not retry a resolved dead letter", func(t *testing.T) {
+			txn := newTxn(NewMemoryDeadLetterStore[int]())
+			_, _ = txn.Run()
+
+			entries, err := txn.DeadLetters()
+			odize.AssertNoError(t, err)
+
+			_, err = txn.RetryDeadLetter(entries[1].ID)
+			odize.AssertNoError(t, err)
+
+			_, err = txn.RetryDeadLetter(entries[1].ID)
+			odize.AssertTrue(t, errors.Is(err, ErrDeadLetterResolved))
+			odize.AssertEqual(t, 1, firstRollbackCalls)
+		}).
+		Test("should not retry a step without a rollback", func(t *testing.T) {
+			store := NewMemoryDeadLetterStore[int]()
+			_, _ = newTxn(store).Run()
+
+			entries, err := store.List()
+			odize.AssertNoError(t, err)
+			odize.AssertEqual(t, 1, entries[1].Step)
+
+			txn := NewTxn(0, TxnOptDeadLetter[int](store)).Step(
+				func(state int) (int, error) { return state, nil },
+				nil,
+			)
+			_, err = txn.RetryDeadLetter(entries[1].ID)
+			odize.AssertEqual(t, "retry failed: step 1: step has no rollback", err.Error())
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
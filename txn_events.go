@@ -0,0 +1,166 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EventTxn - event sourced variant of Txn.
+// Steps emit events rather than returning a new state, the state is derived by folding the events with a reducer.
+type EventTxn[T any, E any] struct {
+	initial T
+	reducer EventReducerFunc[T, E]
+	events  []E
+	errors  []error
+	steps   []EventTxnStep[T, E]
+
+	// currentStep - index of the step currently being executed.
+	currentStep int
+	// failFast - if set to true, the transaction will stop at the first error.
+	failFast bool
+	// verbose - if set to true, the transaction will log out the steps as they are run.
+	verbose bool
+}
+
+// EventTxnFunc - function that receives the current state and returns the events to apply to it.
+type EventTxnFunc[T any, E any] func(T) ([]E, error)
+
+// EventReducerFunc - function that applies an event to the state and returns the new state.
+type EventReducerFunc[T any, E any] func(state T, event E) T
+
+type EventTxnStep[T any, E any] struct {
+	handler  EventTxnFunc[T, E]
+	rollback EventTxnFunc[T, E]
+}
+
+type EventTxnOpts[T any, E any] func(*EventTxn[T, E])
+
+// NewEventTxn - creates a new event sourced transaction.
+// The reducer is used to fold every emitted event into the state, starting from the provided state.
+func NewEventTxn[T any, E any](state T, reducer EventReducerFunc[T, E], opts ...EventTxnOpts[T, E]) *EventTxn[T, E] {
+	t := &EventTxn[T, E]{
+		initial: state,
+		reducer: reducer,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Step - adds a step to the transaction workflow.
+// All steps must have a handler, the rollback func should emit the inverse events of the handler.
+// The rollback func may be nil for steps with nothing to compensate, they are skipped on rollback.
+func (t *EventTxn[T, E]) Step(handler EventTxnFunc[T, E], rollback EventTxnFunc[T, E]) *EventTxn[T, E] {
+	t.steps = append(t.steps, EventTxnStep[T, E]{handler: handler, rollback: rollback})
+	return t
+}
+
+// Run - runs the transaction.
+// If an error occurs within one of the steps, it will rollback the transaction.
+//
+// Errors caught within the steps and rollback funcs will be able to be unwrapped and inspected using Unwrap() []error.
+func (t *EventTxn[T, E]) Run() (T, error) {
+	t.errors = nil
+	t.log(fmt.Sprintf("starting event transaction with %d steps", len(t.steps)))
+	for index, step := range t.steps {
+
+		t.currentStep = index
+		logStep := index + 1
+		t.log(fmt.Sprintf("step %d: executing", logStep))
+
+		events, err := step.handler(t.State())
+		t.events = append(t.events, events...)
+		if err != nil {
+			t.log(fmt.Sprintf("step %d execution failed: step %s, rolling back", logStep, err))
+
+			errWithCtx := fmt.Errorf("step failed: step %d: %w", logStep, err)
+			t.errors = append(t.errors, errWithCtx)
+
+			if err := t.rollback(); err != nil {
+				// fail fast stops the rollback early and returns first error
+				t.errors = append(t.errors, err)
+			}
+
+			return t.State(), errors.Join(t.errors...)
+		}
+
+		t.log(fmt.Sprintf("step %d: complete", logStep))
+	}
+
+	return t.State(), nil
+}
+
+// State - returns the state derived from folding all events emitted so far over the initial state.
+func (t *EventTxn[T, E]) State() T {
+	result := t.initial
+	for _, event := range t.events {
+		result = t.reducer(result, event)
+	}
+	return result
+}
+
+// Events - returns the events emitted by the handlers and rollbacks, in the order they were emitted.
+func (t *EventTxn[T, E]) Events() []E {
+	result := make([]E, len(t.events))
+	copy(result, t.events)
+	return result
+}
+
+// rollback - rolls back the transaction by appending the events emitted by each rollback func.
+// If failFast is set to true, it will stop at the first error on a rollback handler, otherwise it will continue.
+func (t *EventTxn[T, E]) rollback() error {
+	for i := t.currentStep; i >= 0; i-- {
+		logStep := i + 1
+		step := t.steps[i]
+
+		if step.rollback == nil {
+			t.log(fmt.Sprintf("rollback step %d: no rollback registered, skipping", logStep))
+			continue
+		}
+
+		t.log(fmt.Sprintf("rollback step %d: executing", logStep))
+
+		events, err := step.rollback(t.State())
+		t.events = append(t.events, events...)
+		if err != nil {
+			t.log(fmt.Sprintf("rollback step %d: failed: %s", logStep, err))
+
+			errWithCtx := fmt.Errorf("rollback failed: step %d: %w", logStep, err)
+			if t.failFast {
+				return errWithCtx
+			}
+
+			// add it to the list, but continue with rollback
+			t.errors = append(t.errors, errWithCtx)
+		}
+
+		t.log(fmt.Sprintf("rollback step %d: complete", logStep))
+	}
+
+	return nil
+}
+
+func (t *EventTxn[T, E]) log(msg string) {
+	if !t.verbose {
+		return
+	}
+
+	fmt.Println(msg)
+}
+
+// EventTxnOptFailFast - if set to true, the transaction will stop at the first error.
+func EventTxnOptFailFast[T any, E any]() EventTxnOpts[T, E] {
+	return func(t *EventTxn[T, E]) {
+		t.failFast = true
+	}
+}
+
+// EventTxnOptVerbose - if set to true, the transaction will log out the steps as they are run.
+func EventTxnOptVerbose[T any, E any]() EventTxnOpts[T, E] {
+	return func(t *EventTxn[T, E]) {
+		t.verbose = true
+	}
+}
@@ -0,0 +1,200 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+type accountEvent struct {
+	Kind   string
+	Amount int
+}
+
+func applyAccountEvent(balance int, event accountEvent) int {
+	switch event.Kind {
+	case "deposited":
+		return balance + event.Amount
+	case "withdrawn":
+		return balance - event.Amount
+	}
+	return balance
+}
+
+func TestEventTxn(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should fold events into state", func(t *testing.T) {
+			txn := NewEventTxn(10, applyAccountEvent, EventTxnOptVerbose[int, accountEvent]())
+			result, err := txn.
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "deposited", Amount: 5}}, nil
+					},
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "withdrawn", Amount: 5}}, nil
+					},
+				).
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						odize.AssertEqual(t, 15, balance)
+						return []accountEvent{{Kind: "withdrawn", Amount: 3}}, nil
+					},
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "deposited", Amount: 3}}, nil
+					},
+				).
+				Run()
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, 12, result)
+			odize.AssertEqual(t, []accountEvent{
+				{Kind: "deposited", Amount: 5},
+				{Kind: "withdrawn", Amount: 3},
+			}, txn.Events())
+		}).
+		Test("should emit inverse events on rollback", func(t *testing.T) {
+			txn := NewEventTxn(10, applyAccountEvent)
+			result, err := txn.
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "deposited", Amount: 5}}, nil
+					},
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "withdrawn", Amount: 5}}, nil
+					},
+				).
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return nil, fmt.Errorf("expected failure")
+					},
+					func(balance int) ([]accountEvent, error) {
+						return nil, nil
+					},
+				).
+				Run()
+			odize.AssertEqual(t, fmt.Errorf("step failed: step 2: expected failure").Error(), err.Error())
+
+			odize.AssertEqual(t, 10, result)
+			odize.AssertEqual(t, []accountEvent{
+				{Kind: "deposited", Amount: 5},
+				{Kind: "withdrawn", Amount: 5},
+			}, txn.Events())
+		}).
+		Test("should only report first rollback error on fail fast", func(t *testing.T) {
+			rollbackCall := 0
+			expectedErr := fmt.Errorf("rollback fail step 2")
+
+			txn := NewEventTxn(10, applyAccountEvent, EventTxnOptFailFast[int, accountEvent]())
+			_, err := txn.
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return nil, nil
+					},
+					func(balance int) ([]accountEvent, error) {
+						rollbackCall++
+						return nil, fmt.Errorf("rollback fail step 1")
+					},
+				).
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return nil, fmt.Errorf("expected failure")
+					},
+					func(balance int) ([]accountEvent, error) {
+						rollbackCall++
+						return nil, expectedErr
+					},
+				).
+				Run()
+			odize.AssertTrue(t, errors.Is(err, expectedErr))
+
+			odize.AssertEqual(t, 1, rollbackCall)
+		}).
+		Test("should skip steps without a rollback", func(t *testing.T) {
+			txn := NewEventTxn(10, applyAccountEvent)
+			result, err := txn.
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "deposited", Amount: 5}}, nil
+					},
+					func(balance int) ([]accountEvent, error) {
+						return []accountEvent{{Kind: "withdrawn", Amount: 5}}, nil
+					},
+				).
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return nil, nil
+					},
+					nil,
+				).
+				Step(
+					func(balance int) ([]accountEvent, error) {
+						return nil, fmt.Errorf("expected failure")
+					},
+					nil,
+				).
+				Run()
+			odize.AssertEqual(t, "step failed: step 3: expected failure", err.Error())
+
+			odize.AssertEqual(t, 10, result)
+		}).
+		Test("should not report errors from an earlier run", func(t *testing.T) {
+			runs := 0
+			txn := NewEventTxn(10, applyAccountEvent).Step(
+				func(balance int) ([]accountEvent, error) {
+					runs++
+					return nil, fmt.Errorf("expected failure %d", runs)
+				},
+				func(balance int) ([]accountEvent, error) {
+					return nil, nil
+				},
+			)
+
+			_, err := txn.Run()
+			odize.AssertEqual(t, "step failed: step 1: expected failure 1", err.Error())
+
+			_, err = txn.Run()
+			odize.AssertEqual(t, "step failed: step 1: expected failure 2", err.Error())
+		}).
+		Test("should not expose internal event log", func(t *testing.T) {
+			txn := NewEventTxn(0, applyAccountEvent).Step(
+				func(balance int) ([]accountEvent, error) {
+					return []accountEvent{{Kind: "deposited", Amount: 1}}, nil
+				},
+				func(balance int) ([]accountEvent, error) {
+					return nil, nil
+				},
+			)
+			_, err := txn.Run()
+			odize.AssertNoError(t, err)
+
+			events := txn.Events()
+			events[0].Amount = 100
+
+			odize.AssertEqual(t, 1, txn.State())
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExampleEventTxn() {
+	txn := NewEventTxn(10, applyAccountEvent)
+	result, err := txn.Step(
+		func(balance int) ([]accountEvent, error) {
+			return []accountEvent{{Kind: "deposited", Amount: 5}}, nil
+		},
+		func(balance int) ([]accountEvent, error) {
+			return []accountEvent{{Kind: "withdrawn", Amount: 5}}, nil
+		},
+	).Run()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result, txn.Events())
+	// Output: 15 [{deposited 5}]
+}
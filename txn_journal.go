@@ -0,0 +1,69 @@
+package mewl
+
+import (
+	"sync"
+	"time"
+)
+
+// TxnJournal - append only log of the decisions made by a transaction coordinator.
+// Implementations should persist entries durably so that in doubt transactions can be recovered.
+type TxnJournal interface {
+	// Append - appends an entry to the journal.
+	Append(entry TxnJournalEntry) error
+	// Entries - returns all entries for a transaction, in the order they were appended.
+	Entries(txnID string) ([]TxnJournalEntry, error)
+}
+
+// TxnJournalRecord - kind of record written to the journal.
+type TxnJournalRecord string
+
+const (
+	// TxnJournalPrepared - all participants voted to commit.
+	TxnJournalPrepared TxnJournalRecord = "prepared"
+	// TxnJournalCommit - the coordinator decided to commit.
+	TxnJournalCommit TxnJournalRecord = "commit"
+	// TxnJournalAbort - the coordinator decided to abort.
+	TxnJournalAbort TxnJournalRecord = "abort"
+	// TxnJournalComplete - every participant has applied the decision.
+	TxnJournalComplete TxnJournalRecord = "complete"
+)
+
+// TxnJournalEntry - single entry within the journal.
+type TxnJournalEntry struct {
+	TxnID  string
+	Record TxnJournalRecord
+	Time   time.Time
+}
+
+// MemoryTxnJournal - in memory journal, safe for concurrent use.
+// Entries are lost when the process exits, use a durable implementation in production.
+type MemoryTxnJournal struct {
+	mu      sync.Mutex
+	entries map[string][]TxnJournalEntry
+}
+
+// NewMemoryTxnJournal - creates a new in memory journal.
+func NewMemoryTxnJournal() *MemoryTxnJournal {
+	return &MemoryTxnJournal{
+		entries: map[string][]TxnJournalEntry{},
+	}
+}
+
+// Append - appends an entry to the journal.
+func (j *MemoryTxnJournal) Append(entry TxnJournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries[entry.TxnID] = append(j.entries[entry.TxnID], entry)
+	return nil
+}
+
+// Entries - returns all entries for a transaction, in the order they were appended.
+func (j *MemoryTxnJournal) Entries(txnID string) ([]TxnJournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	result := make([]TxnJournalEntry, len(j.entries[txnID]))
+	copy(result, j.entries[txnID])
+	return result, nil
+}
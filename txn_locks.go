@@ -0,0 +1,150 @@
+package mewl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrLockTimeout - returned when a lock could not be acquired before the timeout.
+var ErrLockTimeout = errors.New("lock timeout")
+
+// LockManager - acquires exclusive locks on resource keys on behalf of an owner.
+type LockManager interface {
+	// Acquire - blocks until the key is locked by owner, or the context is done.
+	Acquire(ctx context.Context, owner string, key string) error
+	// Release - releases a key locked by owner.
+	Release(owner string, key string) error
+}
+
+// MemoryLockManager - in process lock manager, safe for concurrent use.
+type MemoryLockManager struct {
+	mu     sync.Mutex
+	locks  map[string]chan struct{}
+	owners map[string]string
+}
+
+// NewMemoryLockManager - creates a new in process lock manager.
+func NewMemoryLockManager() *MemoryLockManager {
+	return &MemoryLockManager{
+		locks:  map[string]chan struct{}{},
+		owners: map[string]string{},
+	}
+}
+
+// Acquire - blocks until the key is locked by owner, or the context is done.
+func (m *MemoryLockManager) Acquire(ctx context.Context, owner string, key string) error {
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		m.mu.Lock()
+		m.owners[key] = owner
+		m.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %s: %w", ErrLockTimeout, key, ctx.Err())
+	}
+}
+
+// Release - releases a key locked by owner.
+func (m *MemoryLockManager) Release(owner string, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.owners[key] != owner {
+		return fmt.Errorf("release failed: %s: not locked by %s", key, owner)
+	}
+
+	delete(m.owners, key)
+	<-m.locks[key]
+	return nil
+}
+
+// lockKeys - returns the unique keys declared by all steps against the state, in acquisition order.
+func (t *Txn[T]) lockKeys(state T) []string {
+	var keys []string
+	for _, step := range t.steps {
+		if step.locks != nil {
+			keys = append(keys, step.locks(state)...)
+		}
+	}
+
+	keys = Unique(keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// acquireLocks - acquires the locks declared by every step, returning a func to release them.
+// Locks are acquired in sorted order, so transactions locking overlapping keys cannot deadlock.
+func (t *Txn[T]) acquireLocks(ctx context.Context, span Span) (func(), error) {
+	release := func() {}
+	if t.lockManager == nil {
+		return release, nil
+	}
+
+	keys := t.lockKeys(*t.txnState.state)
+	if len(keys) == 0 {
+		return release, nil
+	}
+
+	if t.lockTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.lockTimeout)
+		defer cancel()
+	}
+
+	var acquired []string
+	release = func() {
+		for i := len(acquired) - 1; i >= 0; i-- {
+			if err := t.lockManager.Release(t.id, acquired[i]); err != nil {
+				t.log(fmt.Sprintf("lock %s: release failed: %s", acquired[i], err))
+			}
+		}
+	}
+
+	for _, key := range keys {
+		start := time.Now()
+		if err := t.lockManager.Acquire(ctx, t.id, key); err != nil {
+			release()
+			return func() {}, fmt.Errorf("lock failed: %w", err)
+		}
+
+		wait := time.Since(start)
+		acquired = append(acquired, key)
+		t.log(fmt.Sprintf("lock %s: acquired after %s", key, wait))
+		span.AddEvent("lock acquired", map[string]any{
+			"txn.lock.key":  key,
+			"txn.lock.wait": wait.String(),
+		})
+	}
+
+	return release, nil
+}
+
+// TxnStepOptLocks - declares the resource keys the step locks, evaluated against the initial state.
+// Locks are only taken when the transaction has a lock manager, see TxnOptLockManager.
+func TxnStepOptLocks[T any](fn func(T) []string) TxnStepOpts[T] {
+	return func(s *TxnStep[T]) {
+		s.locks = fn
+	}
+}
+
+// TxnOptLockManager - locks the resource keys declared by steps before the first step runs.
+// Locks are released once the transaction commits or has been rolled back.
+// A timeout of zero waits for the locks until the run's context is done.
+func TxnOptLockManager[T any](manager LockManager, timeout time.Duration) TxnOpts[T] {
+	return func(t *Txn[T]) {
+		t.lockManager = manager
+		t.lockTimeout = timeout
+	}
+}
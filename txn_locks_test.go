@@ -0,0 +1,130 @@
+package mewl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxnOptLockManager(t *testing.T) {
+	type transfer struct {
+		From string
+		To   string
+	}
+
+	lockAccounts := TxnStepOptLocks(func(tr transfer) []string {
+		return []string{tr.From, tr.To}
+	})
+
+	noop := func(tr transfer) (transfer, error) {
+		return tr, nil
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should lock declared keys for the duration of the run", func(t *testing.T) {
+			locks := NewMemoryLockManager()
+
+			txn := NewTxn(transfer{From: "a", To: "b"}, TxnOptLockManager[transfer](locks, time.Second))
+			_, err := txn.Step(
+				func(tr transfer) (transfer, error) {
+					ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+					defer cancel()
+
+					err := locks.Acquire(ctx, "other", "a")
+					odize.AssertTrue(t, errors.Is(err, ErrLockTimeout))
+					return tr, nil
+				},
+				noop,
+				lockAccounts,
+			).Run()
+			odize.AssertNoError(t, err)
+
+			odize.AssertNoError(t, locks.Acquire(context.Background(), "other", "a"))
+			odize.AssertNoError(t, locks.Acquire(context.Background(), "other", "b"))
+		}).
+		Test("should release locks after rollback", func(t *testing.T) {
+			locks := NewMemoryLockManager()
+
+			txn := NewTxn(transfer{From: "a", To: "b"}, TxnOptLockManager[transfer](locks, time.Second))
+			_, err := txn.Step(
+				func(tr transfer) (transfer, error) {
+					return tr, fmt.Errorf("expected failure")
+				},
+				noop,
+				lockAccounts,
+			).Run()
+			odize.AssertError(t, err)
+
+			odize.AssertNoError(t, locks.Acquire(context.Background(), "other", "a"))
+		}).
+		Test("should fail without running steps when lock times out", func(t *testing.T) {
+			locks := NewMemoryLockManager()
+			odize.AssertNoError(t, locks.Acquire(context.Background(), "other", "b"))
+
+			handlerCall := 0
+			txn := NewTxn(transfer{From: "a", To: "b"}, TxnOptLockManager[transfer](locks, time.Millisecond))
+			_, err := txn.Step(
+				func(tr transfer) (transfer, error) {
+					handlerCall++
+					return tr, nil
+				},
+				noop,
+				lockAccounts,
+			).Run()
+			odize.AssertTrue(t, errors.Is(err, ErrLockTimeout))
+			odize.AssertEqual(t, 0, handlerCall)
+
+			// keys acquired before the timeout are released
+			odize.AssertNoError(t, locks.Acquire(context.Background(), "other", "a"))
+		}).
+		Test("should not deadlock when transactions lock keys in opposite order", func(t *testing.T) {
+			locks := NewMemoryLockManager()
+
+			var wg sync.WaitGroup
+			errs := make([]error, 20)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					tr := transfer{From: "a", To: "b"}
+					if i%2 == 0 {
+						tr = transfer{From: "b", To: "a"}
+					}
+
+					_, errs[i] = NewTxn(tr, TxnOptLockManager[transfer](locks, time.Second)).
+						Step(noop, noop, lockAccounts).
+						Run()
+				}(i)
+			}
+			wg.Wait()
+
+			odize.AssertNoError(t, errors.Join(errs...))
+		}).
+		Test("should report lock waits as span events", func(t *testing.T) {
+			tracer := NewRecordingTracer()
+
+			txn := NewTxn(
+				transfer{From: "a", To: "b"},
+				TxnOptLockManager[transfer](NewMemoryLockManager(), time.Second),
+				TxnOptTracer[transfer](tracer),
+			)
+			_, err := txn.Step(noop, noop, lockAccounts).Run()
+			odize.AssertNoError(t, err)
+
+			events := tracer.Spans()[0].Events
+			odize.AssertEqual(t, 2, len(events))
+			odize.AssertEqual(t, "lock acquired", events[0].Name)
+			odize.AssertEqual(t, "a", events[0].Attributes["txn.lock.key"])
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,60 @@
+package mewl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TxnPlan - describes what a transaction would do if it was run.
+type TxnPlan struct {
+	Steps []TxnPlanStep
+}
+
+// TxnPlanStep - describes a single step within a TxnPlan.
+type TxnPlanStep struct {
+	// Step - position of the step within the transaction, starting at 1.
+	Step int
+	// Preview - description of the step's effect, empty if the step has no preview func.
+	Preview string
+	// Rollback - true if the step has a compensation registered.
+	Rollback bool
+}
+
+// Plan - evaluates the transaction against the initial state without running any handlers or rollbacks.
+// Steps are reported in the order they would run.
+func (t *Txn[T]) Plan() TxnPlan {
+	plan := TxnPlan{}
+
+	for index, step := range t.steps {
+		planStep := TxnPlanStep{
+			Step:     index + 1,
+			Rollback: step.rollback != nil,
+		}
+
+		if step.preview != nil {
+			planStep.Preview = step.preview(*t.txnState.state)
+		}
+
+		plan.Steps = append(plan.Steps, planStep)
+	}
+
+	return plan
+}
+
+// String - renders the plan with one line per step.
+func (p TxnPlan) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("transaction plan with %d steps", len(p.Steps)))
+	for _, step := range p.Steps {
+		sb.WriteString(fmt.Sprintf("\nstep %d: run", step.Step))
+		if step.Preview != "" {
+			sb.WriteString(fmt.Sprintf(": %s", step.Preview))
+		}
+		if step.Rollback {
+			sb.WriteString(" (rollback registered)")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,122 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxn_Plan(t *testing.T) {
+	type testState struct {
+		Name string
+	}
+
+	state := testState{Name: "hello"}
+
+	group := odize.NewGroup(t, nil)
+	group.AfterEach(func() {
+		state = testState{Name: "hello"}
+	})
+
+	err := group.
+		Test("should not run any handlers", func(t *testing.T) {
+			handlerCall := 0
+			txn := NewTxn(state).Step(
+				func(ts testState) (testState, error) {
+					handlerCall++
+					return ts, nil
+				},
+				func(ts testState) (testState, error) {
+					handlerCall++
+					return ts, nil
+				},
+			)
+
+			plan := txn.Plan()
+
+			odize.AssertEqual(t, 0, handlerCall)
+			odize.AssertEqual(t, 1, len(plan.Steps))
+		}).
+		Test("should report steps in order with previews", func(t *testing.T) {
+			txn := NewTxn(state).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					func(ts testState) (testState, error) { return ts, nil },
+					TxnStepOptPreview(func(ts testState) string {
+						return "rename " + ts.Name
+					}),
+				).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					nil,
+				)
+
+			plan := txn.Plan()
+
+			odize.AssertEqual(t, []TxnPlanStep{
+				{Step: 1, Preview: "rename hello", Rollback: true},
+				{Step: 2, Rollback: false},
+			}, plan.Steps)
+		}).
+		Test("should run a planned step without a rollback", func(t *testing.T) {
+			rollbacks := 0
+			expectedErr := errors.New("expected failure")
+			txn := NewTxn(state).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					func(ts testState) (testState, error) {
+						rollbacks++
+						return ts, nil
+					},
+				).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					nil,
+				).
+				Step(
+					func(ts testState) (testState, error) { return ts, expectedErr },
+					nil,
+				)
+
+			odize.AssertFalse(t, txn.Plan().Steps[1].Rollback)
+
+			_, err := txn.Run()
+			odize.AssertTrue(t, errors.Is(err, expectedErr))
+			odize.AssertEqual(t, 1, rollbacks)
+		}).
+		Test("should return empty plan with no steps", func(t *testing.T) {
+			plan := NewTxn(state).Plan()
+
+			odize.AssertEqual(t, 0, len(plan.Steps))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExampleTxn_Plan() {
+	type testState struct {
+		Name string
+	}
+
+	txn := NewTxn(testState{Name: "hello"}).Step(
+		func(ts testState) (testState, error) {
+			ts.Name = "world"
+			return ts, nil
+		},
+		func(ts testState) (testState, error) {
+			ts.Name = "failed"
+			return ts, nil
+		},
+		TxnStepOptPreview(func(ts testState) string {
+			return fmt.Sprintf("rename %s to world", ts.Name)
+		}),
+	)
+
+	fmt.Println(txn.Plan())
+	// Output:
+	// transaction plan with 1 steps
+	// step 1: run: rename hello to world (rollback registered)
+}
@@ -0,0 +1,82 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Stage - typed saga where each step maps the previous step's output to a new type.
+// Build with NewStage and StageThen, the compiler checks each step accepts the previous step's output.
+type Stage[In any, Out any] struct {
+	// steps - number of steps within the stage, including previous stages.
+	steps int
+	run   func(In) (Out, []stageRollback, error)
+}
+
+type stageRollback struct {
+	step     int
+	rollback func() error
+}
+
+// StageFunc - function that maps the previous step's output to the next.
+type StageFunc[In any, Out any] func(In) (Out, error)
+
+// StageRollbackFunc - function that compensates a step, receiving the step's output.
+type StageRollbackFunc[Out any] func(Out) error
+
+// NewStage - creates a typed saga with a single step.
+func NewStage[In any, Out any](handler StageFunc[In, Out], rollback StageRollbackFunc[Out]) *Stage[In, Out] {
+	return &Stage[In, Out]{
+		steps: 1,
+		run: func(input In) (Out, []stageRollback, error) {
+			out, err := handler(input)
+			rollbacks := []stageRollback{{step: 1, rollback: func() error { return rollback(out) }}}
+			if err != nil {
+				return out, rollbacks, fmt.Errorf("step failed: step 1: %w", err)
+			}
+			return out, rollbacks, nil
+		},
+	}
+}
+
+// StageThen - adds a step to the saga, mapping the previous step's output to a new type.
+func StageThen[In any, Mid any, Out any](prev *Stage[In, Mid], handler StageFunc[Mid, Out], rollback StageRollbackFunc[Out]) *Stage[In, Out] {
+	step := prev.steps + 1
+
+	return &Stage[In, Out]{
+		steps: step,
+		run: func(input In) (Out, []stageRollback, error) {
+			mid, rollbacks, err := prev.run(input)
+			if err != nil {
+				var nilValue Out
+				return nilValue, rollbacks, err
+			}
+
+			out, err := handler(mid)
+			rollbacks = append(rollbacks, stageRollback{step: step, rollback: func() error { return rollback(out) }})
+			if err != nil {
+				return out, rollbacks, fmt.Errorf("step failed: step %d: %w", step, err)
+			}
+			return out, rollbacks, nil
+		},
+	}
+}
+
+// Run - runs the saga.
+// If an error occurs within one of the steps, every step up to and including the failed step is rolled back in reverse order.
+// Errors caught within the steps and rollback funcs will be able to be unwrapped and inspected using Unwrap() []error.
+func (s *Stage[In, Out]) Run(input In) (Out, error) {
+	out, rollbacks, err := s.run(input)
+	if err == nil {
+		return out, nil
+	}
+
+	errs := []error{err}
+	for i := len(rollbacks) - 1; i >= 0; i-- {
+		if rbErr := rollbacks[i].rollback(); rbErr != nil {
+			errs = append(errs, fmt.Errorf("rollback failed: step %d: %w", rollbacks[i].step, rbErr))
+		}
+	}
+
+	return out, errors.Join(errs...)
+}
@@ -0,0 +1,142 @@
+package mewl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestStage(t *testing.T) {
+	type order struct {
+		ID    string
+		Total int
+	}
+
+	var rolledBack []string
+
+	parse := NewStage(
+		func(raw string) (int, error) {
+			return strconv.Atoi(raw)
+		},
+		func(total int) error {
+			rolledBack = append(rolledBack, fmt.Sprintf("parse %d", total))
+			return nil
+		},
+	)
+
+	group := odize.NewGroup(t, nil)
+	group.AfterEach(func() {
+		rolledBack = nil
+	})
+
+	err := group.
+		Test("should pass each step's output to the next step", func(t *testing.T) {
+			saga := StageThen(parse,
+				func(total int) (order, error) {
+					return order{ID: "1", Total: total}, nil
+				},
+				func(o order) error {
+					return nil
+				},
+			)
+
+			result, err := saga.Run("42")
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, order{ID: "1", Total: 42}, result)
+			odize.AssertEqual(t, 0, len(rolledBack))
+		}).
+		Test("should rollback with each step's output in reverse order", func(t *testing.T) {
+			expectedErr := errors.New("expected failure")
+			saga := StageThen(
+				StageThen(parse,
+					func(total int) (order, error) {
+						return order{ID: "1", Total: total}, nil
+					},
+					func(o order) error {
+						rolledBack = append(rolledBack, "order "+o.ID)
+						return nil
+					},
+				),
+				func(o order) (string, error) {
+					return "", expectedErr
+				},
+				func(receipt string) error {
+					rolledBack = append(rolledBack, "receipt")
+					return nil
+				},
+			)
+
+			_, err := saga.Run("42")
+			odize.AssertTrue(t, errors.Is(err, expectedErr))
+			odize.AssertEqual(t, "step failed: step 3: expected failure", err.Error())
+
+			odize.AssertEqual(t, []string{"receipt", "order 1", "parse 42"}, rolledBack)
+		}).
+		Test("should not run later steps on failure", func(t *testing.T) {
+			handlerCall := 0
+			saga := StageThen(parse,
+				func(total int) (order, error) {
+					handlerCall++
+					return order{}, nil
+				},
+				func(o order) error {
+					rolledBack = append(rolledBack, "order")
+					return nil
+				},
+			)
+
+			_, err := saga.Run("not a number")
+			odize.AssertTrue(t, errors.Is(err, strconv.ErrSyntax))
+
+			odize.AssertEqual(t, 0, handlerCall)
+			odize.AssertEqual(t, []string{"parse 0"}, rolledBack)
+		}).
+		Test("should report rollback errors", func(t *testing.T) {
+			rollbackErr := errors.New("rollback failure")
+			saga := StageThen(
+				NewStage(
+					func(raw string) (int, error) { return len(raw), nil },
+					func(total int) error { return rollbackErr },
+				),
+				func(total int) (int, error) { return 0, errors.New("expected failure") },
+				func(total int) error { return nil },
+			)
+
+			_, err := saga.Run("hello")
+			odize.AssertTrue(t, errors.Is(err, rollbackErr))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func ExampleStageThen() {
+	saga := StageThen(
+		NewStage(
+			func(raw string) (int, error) {
+				return strconv.Atoi(raw)
+			},
+			func(total int) error {
+				return nil
+			},
+		),
+		func(total int) (string, error) {
+			return fmt.Sprintf("total: %d", total), nil
+		},
+		func(receipt string) error {
+			return nil
+		},
+	)
+
+	result, err := saga.Run("42")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(result)
+	// Output: total: 42
+}
@@ -0,0 +1,240 @@
+package mewl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TxnTimeline - recording of a transaction run, see TxnOptTimeline.
+type TxnTimeline struct {
+	TxnID string    `json:"txnId"`
+	Start time.Time `json:"start"`
+	// Initial - JSON encoded initial state.
+	Initial json.RawMessage    `json:"initial"`
+	Events  []TxnTimelineEvent `json:"events"`
+}
+
+// TxnTimelineEvent - a single handler or rollback func invocation.
+type TxnTimelineEvent struct {
+	// Elapsed - time since the run started, measured with the monotonic clock.
+	Elapsed time.Duration `json:"elapsed"`
+	// Kind - either "step" or "rollback".
+	Kind string `json:"kind"`
+	// Step - step the event belongs to, starting at 1.
+	Step int `json:"step"`
+	// Status - either "complete", "failed" or "skipped" for a step without a rollback func.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Output - JSON encoded state returned by the handler or rollback func.
+	Output json.RawMessage `json:"output"`
+	// Diff - top level fields of the state that changed, empty if the state is not a JSON object.
+	Diff []TxnTimelineChange `json:"diff,omitempty"`
+}
+
+// TxnTimelineChange - change to a single top level field of the state.
+type TxnTimelineChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// String - renders the timeline as text, with one line per event followed by the state diff.
+func (tl TxnTimeline) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("transaction %s timeline", tl.TxnID))
+	for _, event := range tl.Events {
+		sb.WriteString(fmt.Sprintf("\n+%s %s %d: %s", event.Elapsed, event.Kind, event.Step, event.Status))
+		if event.Error != "" {
+			sb.WriteString(fmt.Sprintf(": %s", event.Error))
+		}
+		for _, change := range event.Diff {
+			sb.WriteString(fmt.Sprintf("\n    %s: %s -> %s", change.Field, rawOrNone(change.Before), rawOrNone(change.After)))
+		}
+	}
+
+	return sb.String()
+}
+
+func rawOrNone(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return "<none>"
+	}
+	return string(raw)
+}
+
+type txnTimeline[T any] struct {
+	mu       sync.Mutex
+	started  time.Time
+	timeline TxnTimeline
+}
+
+func (tl *txnTimeline[T]) start(txnID string, state T) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.started = time.Now()
+	tl.timeline = TxnTimeline{
+		TxnID:   txnID,
+		Start:   tl.started,
+		Initial: encodeTimelineState(state),
+	}
+}
+
+func (tl *txnTimeline[T]) record(kind string, logStep int, before T, after T, err error) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	event := TxnTimelineEvent{
+		Elapsed: time.Since(tl.started),
+		Kind:    kind,
+		Step:    logStep,
+		Status:  "complete",
+		Output:  encodeTimelineState(after),
+		Diff:    diffTimelineState(encodeTimelineState(before), encodeTimelineState(after)),
+	}
+	if err != nil {
+		event.Status = "failed"
+		event.Error = err.Error()
+	}
+
+	tl.timeline.Events = append(tl.timeline.Events, event)
+}
+
+// skip - records a rollback func that was skipped because the step has none, the state is unchanged.
+func (tl *txnTimeline[T]) skip(kind string, logStep int, state T) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.timeline.Events = append(tl.timeline.Events, TxnTimelineEvent{
+		Elapsed: time.Since(tl.started),
+		Kind:    kind,
+		Step:    logStep,
+		Status:  "skipped",
+		Output:  encodeTimelineState(state),
+	})
+}
+
+func (tl *txnTimeline[T]) get() TxnTimeline {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	result := tl.timeline
+	result.Events = append([]TxnTimelineEvent(nil), tl.timeline.Events...)
+	return result
+}
+
+// encodeTimelineState - encodes the state as JSON, states that cannot be encoded are recorded as null.
+func encodeTimelineState[T any](state T) json.RawMessage {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return raw
+}
+
+// diffTimelineState - returns the top level fields that differ between two JSON objects.
+func diffTimelineState(before json.RawMessage, after json.RawMessage) []TxnTimelineChange {
+	var beforeFields map[string]json.RawMessage
+	var afterFields map[string]json.RawMessage
+	if json.Unmarshal(before, &beforeFields) != nil || json.Unmarshal(after, &afterFields) != nil {
+		return nil
+	}
+
+	fields := Union(MapKeys(beforeFields), MapKeys(afterFields))
+	sort.Strings(fields)
+
+	var result []TxnTimelineChange
+	for _, field := range fields {
+		if string(beforeFields[field]) == string(afterFields[field]) {
+			continue
+		}
+		result = append(result, TxnTimelineChange{
+			Field:  field,
+			Before: beforeFields[field],
+			After:  afterFields[field],
+		})
+	}
+	return result
+}
+
+// Timeline - returns the timeline of the last run, empty unless TxnOptTimeline is set.
+func (t *Txn[T]) Timeline() TxnTimeline {
+	if t.timeline == nil {
+		return TxnTimeline{}
+	}
+	return t.timeline.get()
+}
+
+// TxnOptTimeline - records a timeline of every handler and rollback func, with the state diff between them.
+// The state must be JSON encodable for outputs and diffs to be recorded.
+func TxnOptTimeline[T any]() TxnOpts[T] {
+	return func(t *Txn[T]) {
+		t.timeline = &txnTimeline[T]{}
+	}
+}
+
+// Replay - re-runs a recorded timeline against stubbed handlers and rollback funcs that return the recorded outputs and errors.
+// The replayed run takes the same path as the recording, use with TxnOptVerbose or TxnOptTimeline to reproduce a failure.
+func Replay[T any](timeline TxnTimeline, opts ...TxnOpts[T]) (T, error) {
+	var initial T
+	if err := json.Unmarshal(timeline.Initial, &initial); err != nil {
+		return initial, fmt.Errorf("replay failed: decoding initial state: %w", err)
+	}
+
+	steps := 0
+	for _, event := range timeline.Events {
+		steps = max(steps, event.Step)
+	}
+
+	txn := NewTxn(initial, opts...)
+	for i := 1; i <= steps; i++ {
+		handler, err := replayFunc[T](timeline, "step", i)
+		if err != nil {
+			return initial, err
+		}
+		rollback, err := replayFunc[T](timeline, "rollback", i)
+		if err != nil {
+			return initial, err
+		}
+		txn.Step(handler, rollback)
+	}
+
+	return txn.Run()
+}
+
+// replayFunc - returns a func that replays the recorded event, or fails if the event was never recorded.
+// Skipped events are replayed as a nil func, so the replayed step has no rollback either.
+func replayFunc[T any](timeline TxnTimeline, kind string, step int) (TxnFunc[T], error) {
+	event, ok := Find(timeline.Events, func(item TxnTimelineEvent, _ int, _ []TxnTimelineEvent) bool {
+		return item.Kind == kind && item.Step == step
+	})
+	if !ok {
+		return func(state T) (T, error) {
+			return state, fmt.Errorf("replay failed: %s %d was not recorded", kind, step)
+		}, nil
+	}
+
+	if event.Status == "skipped" {
+		return nil, nil
+	}
+
+	var output T
+	if err := json.Unmarshal(event.Output, &output); err != nil {
+		return nil, fmt.Errorf("replay failed: decoding %s %d output: %w", kind, step, err)
+	}
+
+	var recordedErr error
+	if event.Status == "failed" {
+		recordedErr = errors.New(event.Error)
+	}
+
+	return func(_ T) (T, error) {
+		return output, recordedErr
+	}, nil
+}
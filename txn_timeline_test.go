@@ -0,0 +1,166 @@
+package mewl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxnOptTimeline(t *testing.T) {
+	type testState struct {
+		Name  string
+		Count int
+	}
+
+	state := testState{Name: "hello"}
+
+	newTxn := func() *Txn[testState] {
+		return NewTxn(state, TxnOptTimeline[testState]()).
+			Step(
+				func(ts testState) (testState, error) {
+					ts.Name = "world"
+					return ts, nil
+				},
+				func(ts testState) (testState, error) {
+					ts.Name = "failed"
+					return ts, nil
+				},
+			).
+			Step(
+				func(ts testState) (testState, error) {
+					ts.Count++
+					return ts, errors.New("expected failure")
+				},
+				func(ts testState) (testState, error) {
+					ts.Count--
+					return ts, nil
+				},
+			)
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should record every handler and rollback", func(t *testing.T) {
+			txn := newTxn()
+			_, err := txn.Run()
+			odize.AssertError(t, err)
+
+			timeline := txn.Timeline()
+			odize.AssertEqual(t, txn.ID(), timeline.TxnID)
+			odize.AssertEqual(t, `{"Name":"hello","Count":0}`, string(timeline.Initial))
+
+			var kinds []string
+			for _, event := range timeline.Events {
+				kinds = append(kinds, fmt.Sprintf("%s %d %s", event.Kind, event.Step, event.Status))
+			}
+			odize.AssertEqual(t, []string{
+				"step 1 complete",
+				"step 2 failed",
+				"rollback 2 complete",
+				"rollback 1 complete",
+			}, kinds)
+			odize.AssertEqual(t, "expected failure", timeline.Events[1].Error)
+		}).
+		Test("should record state diffs", func(t *testing.T) {
+			txn := newTxn()
+			_, _ = txn.Run()
+
+			events := txn.Timeline().Events
+			odize.AssertEqual(t, []TxnTimelineChange{
+				{Field: "Name", Before: json.RawMessage(`"hello"`), After: json.RawMessage(`"world"`)},
+			}, events[0].Diff)
+			odize.AssertEqual(t, "Count", events[1].Diff[0].Field)
+		}).
+		Test("should render as text", func(t *testing.T) {
+			txn := newTxn()
+			_, _ = txn.Run()
+
+			text := txn.Timeline().String()
+			odize.AssertTrue(t, strings.Contains(text, "step 2: failed: expected failure"))
+			odize.AssertTrue(t, strings.Contains(text, `Name: "hello" -> "world"`))
+		}).
+		Test("should round trip as json", func(t *testing.T) {
+			txn := newTxn()
+			_, _ = txn.Run()
+
+			raw, err := json.Marshal(txn.Timeline())
+			odize.AssertNoError(t, err)
+
+			var decoded TxnTimeline
+			odize.AssertNoError(t, json.Unmarshal(raw, &decoded))
+			odize.AssertEqual(t, len(txn.Timeline().Events), len(decoded.Events))
+			odize.AssertEqual(t, txn.Timeline().Events[1].Elapsed, decoded.Events[1].Elapsed)
+		}).
+		Test("should be empty when not enabled", func(t *testing.T) {
+			txn := NewTxn(state)
+			_, _ = txn.Run()
+
+			odize.AssertEqual(t, 0, len(txn.Timeline().Events))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	type testState struct {
+		Name  string
+		Count int
+	}
+
+	txn := NewTxn(testState{Name: "hello"}, TxnOptTimeline[testState]()).
+		Step(
+			func(ts testState) (testState, error) {
+				ts.Name = "world"
+				return ts, nil
+			},
+			func(ts testState) (testState, error) {
+				ts.Name = "failed"
+				return ts, nil
+			},
+		).
+		Step(
+			func(ts testState) (testState, error) {
+				ts.Count++
+				return ts, errors.New("expected failure")
+			},
+			func(ts testState) (testState, error) {
+				ts.Count--
+				return ts, nil
+			},
+		)
+	original, originalErr := txn.Run()
+
+	result, err := Replay[testState](txn.Timeline())
+
+	odize.AssertEqual(t, original, result)
+	odize.AssertEqual(t, originalErr.Error(), err.Error())
+}
+
+func TestReplay_skipped_rollback(t *testing.T) {
+	txn := NewTxn(0, TxnOptTimeline[int]()).
+		Step(
+			func(state int) (int, error) { return state + 1, nil },
+			nil,
+		).
+		Step(
+			func(state int) (int, error) { return state + 1, errors.New("expected failure") },
+			func(state int) (int, error) { return state - 1, nil },
+		)
+	original, originalErr := txn.Run()
+
+	events := txn.Timeline().Events
+	odize.AssertEqual(t, "rollback", events[3].Kind)
+	odize.AssertEqual(t, 1, events[3].Step)
+	odize.AssertEqual(t, "skipped", events[3].Status)
+
+	result, err := Replay[int](txn.Timeline())
+
+	odize.AssertEqual(t, original, result)
+	odize.AssertEqual(t, originalErr.Error(), err.Error())
+}
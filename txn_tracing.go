@@ -0,0 +1,169 @@
+package mewl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Tracer - starts spans, implement to integrate Txn with a tracing library.
+type Tracer interface {
+	// Start - starts a span as a child of any span within the context.
+	// The returned context contains the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span - single unit of work within a trace.
+type Span interface {
+	// SetAttribute - sets an attribute on the span.
+	SetAttribute(key string, value any)
+	// AddEvent - records a point in time event on the span.
+	AddEvent(name string, attributes map[string]any)
+	// RecordError - records an error on the span, nil errors are ignored.
+	RecordError(err error)
+	// End - completes the span.
+	End()
+}
+
+// startStepSpan - starts a span for a step handler or rollback func.
+// kind is either "step" or "rollback".
+func (t *Txn[T]) startStepSpan(ctx context.Context, kind string, index int) (context.Context, Span) {
+	step := t.steps[index]
+
+	name := step.name
+	if name == "" {
+		name = fmt.Sprintf("step %d", index+1)
+	}
+
+	ctx, span := t.tracer.Start(ctx, fmt.Sprintf("%s %s", kind, name))
+	span.SetAttribute("txn.id", t.id)
+	span.SetAttribute("txn.step", index+1)
+	span.SetAttribute("txn.step.name", name)
+	// steps are not retried within a run, every handler and rollback func is a first attempt.
+	span.SetAttribute("txn.step.attempt", 1)
+	return ctx, span
+}
+
+// TxnOptTracer - traces the transaction, with a span for the run and a child span for every step and rollback.
+func TxnOptTracer[T any](tracer Tracer) TxnOpts[T] {
+	return func(t *Txn[T]) {
+		t.tracer = tracer
+	}
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(_ string, _ any)        {}
+func (noopSpan) AddEvent(_ string, _ map[string]any) {}
+func (noopSpan) RecordError(_ error)                 {}
+func (noopSpan) End()                                {}
+
+// RecordedSpan - span captured by a RecordingTracer.
+type RecordedSpan struct {
+	Name string
+	// Parent - name of the parent span, empty for root spans.
+	Parent     string
+	Attributes map[string]any
+	Events     []RecordedSpanEvent
+	Errors     []error
+	Ended      bool
+}
+
+// RecordedSpanEvent - event captured by a RecordingTracer.
+type RecordedSpanEvent struct {
+	Name       string
+	Attributes map[string]any
+}
+
+// RecordingTracer - tracer that keeps spans in memory, useful for tests. Safe for concurrent use.
+type RecordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+// NewRecordingTracer - creates a new recording tracer.
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{}
+}
+
+type recordingSpanKey struct{}
+
+// Start - starts a span as a child of any recorded span within the context.
+func (r *RecordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordingSpan{
+		tracer: r,
+		span: RecordedSpan{
+			Name:       name,
+			Attributes: map[string]any{},
+		},
+	}
+
+	if parent, ok := ctx.Value(recordingSpanKey{}).(*recordingSpan); ok {
+		span.span.Parent = parent.span.Name
+	}
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return context.WithValue(ctx, recordingSpanKey{}, span), span
+}
+
+// Spans - returns a copy of the recorded spans, in the order they were started.
+func (r *RecordingTracer) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]RecordedSpan, len(r.spans))
+	for index, span := range r.spans {
+		recorded := span.span
+		recorded.Attributes = MapClone(span.span.Attributes)
+		recorded.Events = append([]RecordedSpanEvent(nil), span.span.Events...)
+		recorded.Errors = append([]error(nil), span.span.Errors...)
+		result[index] = recorded
+	}
+	return result
+}
+
+type recordingSpan struct {
+	tracer *RecordingTracer
+	span   RecordedSpan
+}
+
+func (s *recordingSpan) SetAttribute(key string, value any) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.Attributes[key] = value
+}
+
+func (s *recordingSpan) AddEvent(name string, attributes map[string]any) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.Events = append(s.span.Events, RecordedSpanEvent{Name: name, Attributes: attributes})
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.span.Ended = true
+}
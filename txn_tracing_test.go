@@ -0,0 +1,108 @@
+package mewl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestTxnOptTracer(t *testing.T) {
+	type testState struct {
+		Name string
+	}
+
+	state := testState{Name: "hello"}
+
+	spanNames := func(spans []RecordedSpan) []string {
+		var result []string
+		for _, span := range spans {
+			result = append(result, span.Name)
+		}
+		return result
+	}
+
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should record a parent span with a child span per step", func(t *testing.T) {
+			tracer := NewRecordingTracer()
+			txn := NewTxn(state, TxnOptTracer[testState](tracer)).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					func(ts testState) (testState, error) { return ts, nil },
+					TxnStepOptName[testState]("reserve"),
+				).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					func(ts testState) (testState, error) { return ts, nil },
+				)
+
+			_, err := txn.Run()
+			odize.AssertNoError(t, err)
+
+			spans := tracer.Spans()
+			odize.AssertEqual(t, []string{"txn", "step reserve", "step step 2"}, spanNames(spans))
+			odize.AssertEqual(t, "committed", spans[0].Attributes["txn.status"])
+			odize.AssertEqual(t, txn.ID(), spans[0].Attributes["txn.id"])
+			odize.AssertEqual(t, "txn", spans[1].Parent)
+			odize.AssertEqual(t, "reserve", spans[1].Attributes["txn.step.name"])
+			odize.AssertEqual(t, 1, spans[1].Attributes["txn.step.attempt"])
+			odize.AssertTrue(t, Every(spans, func(item RecordedSpan, _ int, _ []RecordedSpan) bool {
+				return item.Ended
+			}))
+		}).
+		Test("should record errors and compensation status", func(t *testing.T) {
+			tracer := NewRecordingTracer()
+			expectedErr := errors.New("expected failure")
+			rollbackErr := errors.New("rollback failure")
+
+			txn := NewTxn(state, TxnOptTracer[testState](tracer)).
+				Step(
+					func(ts testState) (testState, error) { return ts, nil },
+					func(ts testState) (testState, error) { return ts, rollbackErr },
+				).
+				Step(
+					func(ts testState) (testState, error) { return ts, expectedErr },
+					func(ts testState) (testState, error) { return ts, nil },
+				)
+
+			_, err := txn.Run()
+			odize.AssertError(t, err)
+
+			spans := tracer.Spans()
+			odize.AssertEqual(t, []string{
+				"txn",
+				"step step 1",
+				"step step 2",
+				"rollback step 2",
+				"rollback step 1",
+			}, spanNames(spans))
+			odize.AssertEqual(t, "rolled_back", spans[0].Attributes["txn.status"])
+			odize.AssertEqual(t, "rollback", spans[0].Events[0].Name)
+			odize.AssertTrue(t, errors.Is(spans[2].Errors[0], expectedErr))
+			odize.AssertEqual(t, "complete", spans[3].Attributes["txn.compensation.status"])
+			odize.AssertEqual(t, "failed", spans[4].Attributes["txn.compensation.status"])
+			odize.AssertTrue(t, errors.Is(spans[4].Errors[0], rollbackErr))
+		}).
+		Test("should mark dead lettered compensations", func(t *testing.T) {
+			tracer := NewRecordingTracer()
+
+			txn := NewTxn(state,
+				TxnOptTracer[testState](tracer),
+				TxnOptDeadLetter[testState](NewMemoryDeadLetterStore[testState]()),
+			).Step(
+				func(ts testState) (testState, error) { return ts, errors.New("expected failure") },
+				func(ts testState) (testState, error) { return ts, errors.New("rollback failure") },
+			)
+
+			_, err := txn.Run()
+			odize.AssertError(t, err)
+
+			spans := tracer.Spans()
+			odize.AssertEqual(t, "dead_lettered", spans[2].Attributes["txn.compensation.status"])
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,248 @@
+package mewl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Participant - resource taking part in a two phase commit.
+type Participant interface {
+	// Prepare - stage the changes and vote to commit by returning nil.
+	// The ctx is cancelled once the prepare timeout elapses, the coordinator then stops waiting and aborts without waiting for Prepare to return.
+	// Abort may therefore be called while Prepare is still running and must discard anything Prepare stages afterwards.
+	Prepare(ctx context.Context, txnID string) error
+	// Commit - apply the staged changes. Must be idempotent, it may be called again during recovery.
+	Commit(ctx context.Context, txnID string) error
+	// Abort - discard the staged changes. Must be idempotent, it may be called again during recovery.
+	Abort(ctx context.Context, txnID string) error
+}
+
+// TwoPhaseTxn - coordinates a two phase commit across participants.
+// Unlike Txn, participants never apply changes that need compensating, they either all commit or all abort.
+type TwoPhaseTxn struct {
+	id           string
+	participants []Participant
+	journal      TxnJournal
+
+	// prepareTimeout - maximum time each participant has to prepare.
+	prepareTimeout time.Duration
+	// verbose - if set to true, the transaction will log out the phases as they are run.
+	verbose bool
+}
+
+type TwoPhaseTxnOpts func(*TwoPhaseTxn)
+
+// NewTwoPhaseTxn - creates a new two phase commit coordinator.
+// By default the transaction has a generated id, an in memory journal and no prepare timeout.
+func NewTwoPhaseTxn(opts ...TwoPhaseTxnOpts) *TwoPhaseTxn {
+	t := &TwoPhaseTxn{
+		id:      uuid.NewString(),
+		journal: NewMemoryTxnJournal(),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// ID - returns the transaction id used when writing to the journal.
+func (t *TwoPhaseTxn) ID() string {
+	return t.id
+}
+
+// Participant - adds a participant to the transaction.
+func (t *TwoPhaseTxn) Participant(p Participant) *TwoPhaseTxn {
+	t.participants = append(t.participants, p)
+	return t
+}
+
+// Run - runs the two phase commit.
+// All participants are prepared in parallel, if any fail or time out every participant is aborted.
+// The decision is written to the journal before it is applied, so a crash during the second phase can be recovered.
+func (t *TwoPhaseTxn) Run(ctx context.Context) error {
+	t.log(fmt.Sprintf("starting two phase transaction %s with %d participants", t.id, len(t.participants)))
+
+	if err := t.prepare(ctx); err != nil {
+		t.log(fmt.Sprintf("prepare failed: %s, aborting", err))
+
+		if journalErr := t.record(TxnJournalAbort); journalErr != nil {
+			return errors.Join(err, journalErr)
+		}
+
+		return errors.Join(err, t.finish(ctx, TxnJournalAbort))
+	}
+
+	if err := t.record(TxnJournalPrepared); err != nil {
+		return err
+	}
+
+	if err := t.record(TxnJournalCommit); err != nil {
+		return err
+	}
+
+	return t.finish(ctx, TxnJournalCommit)
+}
+
+// Recover - resolves an in doubt transaction using the decision recorded in the journal.
+// If a commit was recorded it is re-applied, otherwise the transaction is aborted.
+// Recovering a transaction that has already completed is a no-op.
+func (t *TwoPhaseTxn) Recover(ctx context.Context) error {
+	entries, err := t.journal.Entries(t.id)
+	if err != nil {
+		return fmt.Errorf("recover failed: reading journal: %w", err)
+	}
+
+	decision := TxnJournalAbort
+	for _, entry := range entries {
+		switch entry.Record {
+		case TxnJournalComplete:
+			t.log(fmt.Sprintf("transaction %s already complete", t.id))
+			return nil
+		case TxnJournalCommit:
+			decision = TxnJournalCommit
+		}
+	}
+
+	t.log(fmt.Sprintf("recovering transaction %s: %s", t.id, decision))
+	if len(entries) == 0 {
+		if err := t.record(TxnJournalAbort); err != nil {
+			return err
+		}
+	}
+
+	return t.finish(ctx, decision)
+}
+
+// prepare - prepares all participants in parallel and returns the joined errors of those that failed.
+func (t *TwoPhaseTxn) prepare(ctx context.Context) error {
+	errs := make(chan error, len(t.participants))
+
+	for index, p := range t.participants {
+		go func(index int, p Participant) {
+			errs <- t.prepareParticipant(ctx, index, p)
+		}(index, p)
+	}
+
+	var result []error
+	for range t.participants {
+		if err := <-errs; err != nil {
+			result = append(result, err)
+		}
+	}
+
+	return errors.Join(result...)
+}
+
+// prepareParticipant - prepares a single participant, returning early if the timeout elapses.
+func (t *TwoPhaseTxn) prepareParticipant(ctx context.Context, index int, p Participant) error {
+	if t.prepareTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.prepareTimeout)
+		defer cancel()
+	}
+
+	logParticipant := index + 1
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Prepare(ctx, t.id)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("prepare failed: participant %d: %w", logParticipant, err)
+		}
+		t.log(fmt.Sprintf("participant %d: prepared", logParticipant))
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("prepare failed: participant %d: %w", logParticipant, ctx.Err())
+	}
+}
+
+// finish - applies the decision to every participant, recording completion only if all succeed.
+// Once the decision is recorded it must be applied, so cancelling the ctx does not cancel the second phase.
+func (t *TwoPhaseTxn) finish(ctx context.Context, decision TxnJournalRecord) error {
+	var errs []error
+	ctx = context.WithoutCancel(ctx)
+
+	for index, p := range t.participants {
+		logParticipant := index + 1
+
+		var err error
+		if decision == TxnJournalCommit {
+			err = p.Commit(ctx, t.id)
+		} else {
+			err = p.Abort(ctx, t.id)
+		}
+
+		if err != nil {
+			t.log(fmt.Sprintf("participant %d: %s failed: %s", logParticipant, decision, err))
+			errs = append(errs, fmt.Errorf("%s failed: participant %d: %w", decision, logParticipant, err))
+			continue
+		}
+
+		t.log(fmt.Sprintf("participant %d: %s complete", logParticipant, decision))
+	}
+
+	if len(errs) > 0 {
+		// leave the transaction in doubt so it can be recovered
+		return errors.Join(errs...)
+	}
+
+	return t.record(TxnJournalComplete)
+}
+
+func (t *TwoPhaseTxn) record(record TxnJournalRecord) error {
+	err := t.journal.Append(TxnJournalEntry{
+		TxnID:  t.id,
+		Record: record,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("journal append failed: %s: %w", record, err)
+	}
+
+	return nil
+}
+
+func (t *TwoPhaseTxn) log(msg string) {
+	if !t.verbose {
+		return
+	}
+
+	fmt.Println(msg)
+}
+
+// TwoPhaseTxnOptID - sets the transaction id, used to recover an in doubt transaction.
+func TwoPhaseTxnOptID(id string) TwoPhaseTxnOpts {
+	return func(t *TwoPhaseTxn) {
+		t.id = id
+	}
+}
+
+// TwoPhaseTxnOptJournal - sets the journal the coordinator writes its decisions to.
+func TwoPhaseTxnOptJournal(journal TxnJournal) TwoPhaseTxnOpts {
+	return func(t *TwoPhaseTxn) {
+		t.journal = journal
+	}
+}
+
+// TwoPhaseTxnOptPrepareTimeout - sets the maximum time each participant has to prepare.
+func TwoPhaseTxnOptPrepareTimeout(timeout time.Duration) TwoPhaseTxnOpts {
+	return func(t *TwoPhaseTxn) {
+		t.prepareTimeout = timeout
+	}
+}
+
+// TwoPhaseTxnOptVerbose - if set to true, the transaction will log out the phases as they are run.
+func TwoPhaseTxnOptVerbose() TwoPhaseTxnOpts {
+	return func(t *TwoPhaseTxn) {
+		t.verbose = true
+	}
+}
@@ -0,0 +1,202 @@
+package mewl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+type fakeParticipant struct {
+	mu         sync.Mutex
+	prepareErr error
+	commitErr  error
+	delay      time.Duration
+	calls      []string
+	// finishCtxErr - error of the ctx passed to the last Commit or Abort.
+	finishCtxErr error
+}
+
+func (f *fakeParticipant) Prepare(ctx context.Context, txnID string) error {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	f.called("prepare")
+	return f.prepareErr
+}
+
+func (f *fakeParticipant) Commit(ctx context.Context, _ string) error {
+	f.called("commit")
+	f.finishCtxErr = ctx.Err()
+	return f.commitErr
+}
+
+func (f *fakeParticipant) Abort(ctx context.Context, _ string) error {
+	f.called("abort")
+	f.finishCtxErr = ctx.Err()
+	return nil
+}
+
+func (f *fakeParticipant) called(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, name)
+}
+
+func journalRecords(t *testing.T, journal TxnJournal, id string) []TxnJournalRecord {
+	entries, err := journal.Entries(id)
+	odize.AssertNoError(t, err)
+
+	var result []TxnJournalRecord
+	for _, entry := range entries {
+		result = append(result, entry.Record)
+	}
+	return result
+}
+
+func TestTwoPhaseTxn(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should commit all participants when all prepare", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			p1 := &fakeParticipant{}
+			p2 := &fakeParticipant{}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptJournal(journal), TwoPhaseTxnOptVerbose())
+			err := txn.Participant(p1).Participant(p2).Run(context.Background())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []string{"prepare", "commit"}, p1.calls)
+			odize.AssertEqual(t, []string{"prepare", "commit"}, p2.calls)
+			odize.AssertEqual(t, []TxnJournalRecord{
+				TxnJournalPrepared,
+				TxnJournalCommit,
+				TxnJournalComplete,
+			}, journalRecords(t, journal, txn.ID()))
+		}).
+		Test("should abort all participants when one fails to prepare", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			expectedErr := fmt.Errorf("expected failure")
+			p1 := &fakeParticipant{}
+			p2 := &fakeParticipant{prepareErr: expectedErr}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptJournal(journal))
+			err := txn.Participant(p1).Participant(p2).Run(context.Background())
+			odize.AssertTrue(t, errors.Is(err, expectedErr))
+
+			odize.AssertEqual(t, []string{"prepare", "abort"}, p1.calls)
+			odize.AssertEqual(t, []string{"prepare", "abort"}, p2.calls)
+			odize.AssertEqual(t, []TxnJournalRecord{
+				TxnJournalAbort,
+				TxnJournalComplete,
+			}, journalRecords(t, journal, txn.ID()))
+		}).
+		Test("should abort when a participant times out preparing", func(t *testing.T) {
+			p1 := &fakeParticipant{delay: time.Second}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptPrepareTimeout(time.Millisecond))
+			err := txn.Participant(p1).Run(context.Background())
+			odize.AssertTrue(t, errors.Is(err, context.DeadlineExceeded))
+
+			odize.AssertEqual(t, []string{"abort"}, p1.calls)
+		}).
+		Test("should abort with an uncancelled ctx when the run is cancelled", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			p1 := &fakeParticipant{delay: time.Second}
+			p2 := &fakeParticipant{}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, cancel)
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptJournal(journal))
+			err := txn.Participant(p1).Participant(p2).Run(ctx)
+			odize.AssertTrue(t, errors.Is(err, context.Canceled))
+
+			odize.AssertEqual(t, []string{"abort"}, p1.calls)
+			odize.AssertNoError(t, p1.finishCtxErr)
+			odize.AssertNoError(t, p2.finishCtxErr)
+			odize.AssertEqual(t, []TxnJournalRecord{
+				TxnJournalAbort,
+				TxnJournalComplete,
+			}, journalRecords(t, journal, txn.ID()))
+		}).
+		Test("should leave transaction in doubt when commit fails", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			p1 := &fakeParticipant{commitErr: fmt.Errorf("expected failure")}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptJournal(journal))
+			err := txn.Participant(p1).Run(context.Background())
+			odize.AssertError(t, err)
+
+			odize.AssertEqual(t, []TxnJournalRecord{
+				TxnJournalPrepared,
+				TxnJournalCommit,
+			}, journalRecords(t, journal, txn.ID()))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
+
+func TestTwoPhaseTxn_Recover(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should re-apply commit for in doubt transaction", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			_ = journal.Append(TxnJournalEntry{TxnID: "txn-1", Record: TxnJournalPrepared})
+			_ = journal.Append(TxnJournalEntry{TxnID: "txn-1", Record: TxnJournalCommit})
+			p1 := &fakeParticipant{}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptID("txn-1"), TwoPhaseTxnOptJournal(journal))
+			err := txn.Participant(p1).Recover(context.Background())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []string{"commit"}, p1.calls)
+			odize.AssertEqual(t, []TxnJournalRecord{
+				TxnJournalPrepared,
+				TxnJournalCommit,
+				TxnJournalComplete,
+			}, journalRecords(t, journal, "txn-1"))
+		}).
+		Test("should abort transaction with no decision", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			p1 := &fakeParticipant{}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptID("txn-2"), TwoPhaseTxnOptJournal(journal))
+			err := txn.Participant(p1).Recover(context.Background())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, []string{"abort"}, p1.calls)
+			odize.AssertEqual(t, []TxnJournalRecord{
+				TxnJournalAbort,
+				TxnJournalComplete,
+			}, journalRecords(t, journal, "txn-2"))
+		}).
+		Test("should do nothing for completed transaction", func(t *testing.T) {
+			journal := NewMemoryTxnJournal()
+			_ = journal.Append(TxnJournalEntry{TxnID: "txn-3", Record: TxnJournalCommit})
+			_ = journal.Append(TxnJournalEntry{TxnID: "txn-3", Record: TxnJournalComplete})
+			p1 := &fakeParticipant{}
+
+			txn := NewTwoPhaseTxn(TwoPhaseTxnOptID("txn-3"), TwoPhaseTxnOptJournal(journal))
+			err := txn.Participant(p1).Recover(context.Background())
+			odize.AssertNoError(t, err)
+
+			odize.AssertEqual(t, 0, len(p1.calls))
+		}).
+		Run()
+
+	odize.AssertNoError(t, err)
+}
@@ -0,0 +1,34 @@
+package mewl
+
+// Window - creates a new nested slice of size consecutive elements, starting a window every step elements.
+// Windows overlap when step is less than size, unlike Chunk, and only full windows are returned.
+// A size or step less than 1 is treated as 1. Each window is a copy, the list is not modified.
+func Window[T any](list []T, size int, step int) [][]T {
+	return SeqCollect(SeqWindow(SeqFromSlice(list), size, step))
+}
+
+// Pairwise - pairs every element with the element after it. Lists with fewer than two elements return nil.
+func Pairwise[T any](list []T) []Pair[T, T] {
+	return SeqCollect(SeqPairwise(SeqFromSlice(list)))
+}
+
+// Scan - like Reduce, but returns the accumulator after every element instead of only the final value.
+// initVal is not included in the result.
+func Scan[T any, K any](list []T, initVal K, fn func(acc K, item T) K) []K {
+	return SeqCollect(SeqScan(SeqFromSlice(list), initVal, fn))
+}
+
+// PrefixReduce - returns the first element, then the result of reducing every element so far, such as running totals.
+func PrefixReduce[T any](list []T, fn func(prev T, next T) T) []T {
+	return SeqCollect(SeqPrefixReduce(SeqFromSlice(list), fn))
+}
+
+// RunLengthEncode - compresses runs of equal consecutive elements into the element and the length of the run.
+func RunLengthEncode[T comparable](list []T) []Pair[T, int] {
+	return SeqCollect(SeqRunLengthEncode(SeqFromSlice(list)))
+}
+
+// RunLengthDecode - expands the runs returned by RunLengthEncode. Runs with a length less than 1 are skipped.
+func RunLengthDecode[T any](runs []Pair[T, int]) []T {
+	return SeqCollect(SeqRunLengthDecode(SeqFromSlice(runs)))
+}
@@ -0,0 +1,172 @@
+package mewl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/code-gorilla-au/odize"
+)
+
+func TestWindow(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	list := []int{1, 2, 3, 4, 5, 6}
+
+	err := group.
+		Test("should create overlapping windows", func(t *testing.T) {
+			got := Window(list, 3, 1)
+
+			odize.AssertEqual(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}}, got)
+		}).
+		Test("should step between windows", func(t *testing.T) {
+			got := Window(list, 3, 2)
+
+			odize.AssertEqual(t, [][]int{{1, 2, 3}, {3, 4, 5}}, got)
+		}).
+		Test("should match Chunk when step equals size and the list divides evenly", func(t *testing.T) {
+			odize.AssertEqual(t, Chunk(list, 2), Window(list, 2, 2))
+		}).
+		Test("should skip elements when step is larger than size", func(t *testing.T) {
+			got := Window(list, 2, 3)
+
+			odize.AssertEqual(t, [][]int{{1, 2}, {4, 5}}, got)
+		}).
+		Test("should return nil when the list is shorter than a window", func(t *testing.T) {
+			var expected [][]int
+
+			odize.AssertEqual(t, expected, Window(list, 7, 1))
+		}).
+		Test("should treat size and step less than 1 as 1", func(t *testing.T) {
+			got := Window([]int{1, 2}, 0, -1)
+
+			odize.AssertEqual(t, [][]int{{1}, {2}}, got)
+		}).
+		Test("windows should not share memory", func(t *testing.T) {
+			got := Window(list, 3, 1)
+			got[0][2] = -1
+
+			odize.AssertEqual(t, []int{2, 3, 4}, got[1])
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleWindow() {
+	readings := []float64{2, 4, 6, 8}
+
+	var averages []float64
+	for _, window := range Window(readings, 2, 1) {
+		averages = append(averages, (window[0]+window[1])/2)
+	}
+
+	fmt.Println(averages)
+	// Output: [3 5 7]
+}
+
+func TestPairwise(t *testing.T) {
+	got := Pairwise([]string{"a", "b", "c"})
+
+	odize.AssertEqual(t, []Pair[string, string]{NewPair("a", "b"), NewPair("b", "c")}, got)
+	odize.AssertTrue(t, Pairwise([]int{1}) == nil)
+	odize.AssertTrue(t, Pairwise([]int{}) == nil)
+}
+
+func ExamplePairwise() {
+	var deltas []int
+	for _, pair := range Pairwise([]int{1, 4, 9, 16}) {
+		deltas = append(deltas, pair.Second-pair.First)
+	}
+
+	fmt.Println(deltas)
+	// Output: [3 5 7]
+}
+
+func TestScan(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should return every accumulator", func(t *testing.T) {
+			got := Scan([]string{"a", "bb", "ccc"}, 10, func(acc int, item string) int {
+				return acc + len(item)
+			})
+
+			odize.AssertEqual(t, []int{11, 13, 16}, got)
+		}).
+		Test("should end with the Reduce result", func(t *testing.T) {
+			list := []int{3, 1, 4, 1, 5}
+			add := func(prev int, next int) int { return prev + next }
+
+			got := Scan(list, 0, add)
+
+			odize.AssertEqual(t, Reduce(list, add)(0), got[len(got)-1])
+		}).
+		Test("should return nil for an empty list", func(t *testing.T) {
+			odize.AssertTrue(t, Scan([]int{}, 1, func(acc int, item int) int { return acc }) == nil)
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func TestPrefixReduce(t *testing.T) {
+	maxOf := func(prev int, next int) int { return max(prev, next) }
+
+	got := PrefixReduce([]int{3, 1, 4, 1, 5}, maxOf)
+
+	odize.AssertEqual(t, []int{3, 3, 4, 4, 5}, got)
+	odize.AssertTrue(t, PrefixReduce([]int{}, maxOf) == nil)
+}
+
+func ExamplePrefixReduce() {
+	got := PrefixReduce([]int{1, 2, 3, 4}, func(prev int, next int) int {
+		return prev + next
+	})
+
+	fmt.Println(got)
+	// Output: [1 3 6 10]
+}
+
+func TestRunLengthEncode(t *testing.T) {
+	group := odize.NewGroup(t, nil)
+
+	err := group.
+		Test("should encode runs of equal elements", func(t *testing.T) {
+			got := RunLengthEncode([]string{"a", "a", "b", "a", "c", "c", "c"})
+
+			odize.AssertEqual(t, []Pair[string, int]{
+				NewPair("a", 2),
+				NewPair("b", 1),
+				NewPair("a", 1),
+				NewPair("c", 3),
+			}, got)
+		}).
+		Test("should encode runs of zero values", func(t *testing.T) {
+			got := RunLengthEncode([]int{0, 0, 1})
+
+			odize.AssertEqual(t, []Pair[int, int]{NewPair(0, 2), NewPair(1, 1)}, got)
+		}).
+		Test("should return nil for an empty list", func(t *testing.T) {
+			odize.AssertTrue(t, RunLengthEncode([]int{}) == nil)
+		}).
+		Test("should round trip with RunLengthDecode", func(t *testing.T) {
+			list := []int{1, 1, 1, 2, 3, 3, 1}
+
+			odize.AssertEqual(t, list, RunLengthDecode(RunLengthEncode(list)))
+		}).
+		Test("RunLengthDecode should skip empty runs", func(t *testing.T) {
+			got := RunLengthDecode([]Pair[string, int]{NewPair("a", 2), NewPair("b", 0), NewPair("c", -1), NewPair("d", 1)})
+
+			odize.AssertEqual(t, []string{"a", "a", "d"}, got)
+		}).
+		Run()
+	odize.AssertNoError(t, err)
+}
+
+func ExampleRunLengthEncode() {
+	got := RunLengthEncode([]rune("aaabccdd"))
+
+	for _, run := range got {
+		fmt.Printf("%c%d", run.First, run.Second)
+	}
+	fmt.Println()
+	// Output: a3b1c2d2
+}